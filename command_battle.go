@@ -0,0 +1,376 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GrahamZiervogel/pokedex/internal/battle"
+	"github.com/GrahamZiervogel/pokedex/internal/encounter"
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+// healingItems maps the potions usable in battle to the HP they restore;
+// 0 restores all of it. Each use takes one from the bag.
+var healingItems = map[string]int{
+	"potion":       20,
+	"super-potion": 50,
+	"hyper-potion": 200,
+	"max-potion":   0,
+}
+
+// wildBattle tracks which party member is fighting and the battle state
+// of every party member sent out so far. Party Pokémon are back to full
+// health and PP when the next battle starts.
+type wildBattle struct {
+	*battle.Battle
+	ActiveID       int
+	Team           map[int]*battle.Combatant
+	WildIndividual trainer.Pokemon
+}
+
+var errInBattle = errors.New("you are in a battle; fight, catch or run first")
+
+type battleStatus struct {
+	Active      string       `json:"active"`
+	ActiveHP    int          `json:"active_hp"`
+	ActiveMaxHP int          `json:"active_max_hp"`
+	Wild        string       `json:"wild"`
+	WildHP      int          `json:"wild_hp"`
+	WildMaxHP   int          `json:"wild_max_hp"`
+	Moves       []moveStatus `json:"moves"`
+}
+
+type moveStatus struct {
+	Name  string `json:"name"`
+	PP    int    `json:"pp"`
+	MaxPP int    `json:"max_pp"`
+}
+
+type battleResult struct {
+	Log            []string        `json:"log"`
+	Outcome        string          `json:"outcome"`
+	BlackedOut     bool            `json:"blacked_out,omitempty"`
+	Status         *battleStatus   `json:"status,omitempty"`
+	ExperienceGain *experienceGain `json:"experience_gain,omitempty"`
+}
+
+// startBattle sends the party lead out against a wild encounter. Without a
+// party there is no battle, and balls are thrown at a healthy Pokémon.
+func startBattle(cfg *Config, enc encounter.Encounter) (*wildBattle, error) {
+	lead := partyLead(cfg)
+	if lead == nil {
+		return nil, nil
+	}
+
+	chart := battle.Chart{}
+	player, err := partyCombatant(cfg, lead, chart)
+	if err != nil {
+		return nil, err
+	}
+
+	wildData, err := cfg.PokeapiClient.GetPokemonDetails(enc.Pokemon)
+	if err != nil {
+		return nil, err
+	}
+	group, err := versionGroup(cfg)
+	if err != nil {
+		return nil, err
+	}
+	wildIndividual := trainer.Pokemon{
+		Species: enc.Pokemon,
+		Level:   enc.Level,
+		IVs:     trainer.RandomIVs(cfg.Rand.Intn),
+		Moves:   startingMoves(wildData, group, enc.Level),
+	}
+	wild, err := newCombatant(cfg, "Wild "+enc.Pokemon, wildData, wildIndividual, chart)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wildBattle{
+		Battle:         battle.New(player, wild, chart, cfg.Rand.Intn),
+		ActiveID:       lead.ID,
+		Team:           map[int]*battle.Combatant{lead.ID: player},
+		WildIndividual: wildIndividual,
+	}, nil
+}
+
+func partyLead(cfg *Config) *trainer.Pokemon {
+	if len(cfg.Collection.Party) == 0 {
+		return nil
+	}
+	lead, _ := cfg.Collection.Get(cfg.Collection.Party[0])
+	return lead
+}
+
+func partyCombatant(cfg *Config, individual *trainer.Pokemon, chart battle.Chart) (*battle.Combatant, error) {
+	pokemon, err := cfg.PokeapiClient.GetPokemonDetails(individual.Species)
+	if err != nil {
+		return nil, err
+	}
+	return newCombatant(cfg, individual.Name(), pokemon, *individual, chart)
+}
+
+// newCombatant builds an individual's battle state at full health and
+// makes sure chart covers the types of its moves.
+func newCombatant(cfg *Config, name string, pokemon pokeapi.Pokemon, individual trainer.Pokemon, chart battle.Chart) (*battle.Combatant, error) {
+	stats := individual.ActualStats(baseStats(pokemon))
+	combatant := &battle.Combatant{
+		Name:  name,
+		Level: individual.Level,
+		Stats: stats,
+		HP:    stats["hp"],
+	}
+	for _, typeEntry := range pokemon.Types {
+		combatant.Types = append(combatant.Types, typeEntry.Type.Name)
+	}
+
+	for _, moveName := range individual.Moves {
+		move, err := battleMove(cfg, moveName)
+		if err != nil {
+			return nil, err
+		}
+		if !chart.Has(move.Type) {
+			moveType, err := cfg.PokeapiClient.GetType(move.Type)
+			if err != nil {
+				return nil, err
+			}
+			chart.Add(moveType)
+		}
+		combatant.Moves = append(combatant.Moves, move)
+	}
+	return combatant, nil
+}
+
+func battleMove(cfg *Config, name string) (*battle.Move, error) {
+	move, err := cfg.PokeapiClient.GetMove(name)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &battle.Move{
+		Name:     move.Name,
+		Type:     move.Type.Name,
+		Category: move.DamageClass.Name,
+		PP:       move.PP,
+		MaxPP:    move.PP,
+		Priority: move.Priority,
+	}
+	if move.Power != nil {
+		res.Power = *move.Power
+	}
+	if move.Accuracy != nil {
+		res.Accuracy = *move.Accuracy
+	}
+	if move.Meta != nil {
+		res.CritStage = move.Meta.CritRate
+	}
+	return res, nil
+}
+
+func commandFight(cfg *Config, args ...string) (result, error) {
+	if len(args) != 1 {
+		return nil, errors.New("usage: fight <move>")
+	}
+	if cfg.Battle == nil {
+		return nil, errors.New("you are not in a battle")
+	}
+
+	turn, err := cfg.Battle.Fight(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return finishTurn(cfg, turn)
+}
+
+func commandRun(cfg *Config, args ...string) (result, error) {
+	if len(args) > 0 {
+		return nil, errors.New("run command does not take any arguments")
+	}
+	if cfg.Battle == nil {
+		return nil, errors.New("you are not in a battle")
+	}
+
+	turn, err := cfg.Battle.Run()
+	if err != nil {
+		return nil, err
+	}
+	return finishTurn(cfg, turn)
+}
+
+func commandItem(cfg *Config, args ...string) (result, error) {
+	if len(args) != 1 {
+		return nil, errors.New("usage: item <item>")
+	}
+	if cfg.Battle == nil {
+		return nil, errors.New("you are not in a battle")
+	}
+
+	item := strings.ToLower(args[0])
+	amount, ok := healingItems[item]
+	if !ok {
+		return nil, fmt.Errorf("%s can't be used in battle", item)
+	}
+	if amount == 0 {
+		amount = cfg.Battle.Player.MaxHP()
+	}
+
+	if cfg.Inventory.Count(item) == 0 {
+		return nil, fmt.Errorf("you don't have any %s", item)
+	}
+
+	turn, err := cfg.Battle.Heal(item, amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Inventory.Remove(item, 1); err != nil {
+		return nil, err
+	}
+	return finishTurn(cfg, turn)
+}
+
+func commandSwitch(cfg *Config, args ...string) (result, error) {
+	if len(args) != 1 {
+		return nil, errors.New("usage: switch <pokemon>")
+	}
+	if cfg.Battle == nil {
+		return nil, errors.New("you are not in a battle")
+	}
+
+	individual, err := cfg.Collection.Find(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if place, _ := cfg.Collection.Locate(individual.ID); !place.InParty() {
+		return nil, fmt.Errorf("%s is not in your party", individual.Name())
+	}
+
+	next, ok := cfg.Battle.Team[individual.ID]
+	if !ok {
+		next, err = partyCombatant(cfg, individual, cfg.Battle.Chart)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	turn, err := cfg.Battle.Switch(next)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Battle.Team[individual.ID] = next
+	cfg.Battle.ActiveID = individual.ID
+	return finishTurn(cfg, turn)
+}
+
+// finishTurn applies the outcome of a turn: experience when the wild
+// Pokémon faints, the end of the encounter when the player escapes or
+// runs out of Pokémon, and otherwise the state of both sides.
+func finishTurn(cfg *Config, turn battle.Turn) (*battleResult, error) {
+	res := &battleResult{Log: turn.Log, Outcome: string(turn.Outcome)}
+
+	switch turn.Outcome {
+	case battle.Won:
+		wild := cfg.WildEncounter
+		wildData, err := cfg.PokeapiClient.GetPokemonDetails(wild.Pokemon)
+		if err != nil {
+			return nil, err
+		}
+		active, _ := cfg.Collection.Get(cfg.Battle.ActiveID)
+		res.ExperienceGain, err = awardExperience(cfg, active, wildData, wild.Level)
+		if err != nil {
+			return nil, err
+		}
+		endBattle(cfg)
+	case battle.Escaped:
+		endBattle(cfg)
+	case battle.Fainted:
+		if !canContinue(cfg) {
+			res.BlackedOut = true
+			res.Log = append(res.Log, "You have no more Pokémon that can fight! You blacked out!")
+			endBattle(cfg)
+			break
+		}
+		res.Log = append(res.Log, "Send out another Pokémon with switch.")
+	default:
+		res.Status = currentBattleStatus(cfg.Battle)
+	}
+	return res, nil
+}
+
+func endBattle(cfg *Config) {
+	if cfg.Battle != nil {
+		cfg.Battle.End()
+	}
+	cfg.Battle = nil
+	cfg.WildEncounter = nil
+}
+
+// canContinue reports whether any party member has not fainted yet.
+func canContinue(cfg *Config) bool {
+	for _, id := range cfg.Collection.Party {
+		combatant, sentOut := cfg.Battle.Team[id]
+		if !sentOut || !combatant.Fainted() {
+			return true
+		}
+	}
+	return false
+}
+
+func currentBattleStatus(b *wildBattle) *battleStatus {
+	status := &battleStatus{
+		Active:      b.Player.Name,
+		ActiveHP:    b.Player.HP,
+		ActiveMaxHP: b.Player.MaxHP(),
+		Wild:        b.Wild.Name,
+		WildHP:      b.Wild.HP,
+		WildMaxHP:   b.Wild.MaxHP(),
+		Moves:       []moveStatus{},
+	}
+	for _, move := range b.Player.Moves {
+		status.Moves = append(status.Moves, moveStatus{Name: move.Name, PP: move.PP, MaxPP: move.MaxPP})
+	}
+	return status
+}
+
+func (s *battleStatus) writeText(w io.Writer, p style.Palette) {
+	if s == nil {
+		return
+	}
+	fmt.Fprintf(w, "%s %d/%d HP | %s %d/%d HP\n", p.Bold(s.Active), s.ActiveHP, s.ActiveMaxHP, s.Wild, s.WildHP, s.WildMaxHP)
+
+	moves := make([]string, 0, len(s.Moves))
+	for _, move := range s.Moves {
+		moves = append(moves, fmt.Sprintf("%s (%d/%d)", move.Name, move.PP, move.MaxPP))
+	}
+	if len(moves) == 0 {
+		moves = append(moves, "struggle")
+	}
+	fmt.Fprintf(w, "%s %s\n", p.Header("Moves:"), strings.Join(moves, ", "))
+}
+
+func (s *battleStatus) table() ([]string, [][]string) {
+	return []string{"pokemon", "hp", "max_hp"}, [][]string{
+		{s.Active, fmt.Sprint(s.ActiveHP), fmt.Sprint(s.ActiveMaxHP)},
+		{s.Wild, fmt.Sprint(s.WildHP), fmt.Sprint(s.WildMaxHP)},
+	}
+}
+
+func (r *battleResult) writeText(w io.Writer, p style.Palette) {
+	for _, line := range r.Log {
+		fmt.Fprintln(w, line)
+	}
+	r.ExperienceGain.writeText(w, p)
+	r.Status.writeText(w, p)
+}
+
+func (r *battleResult) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Log))
+	for _, line := range r.Log {
+		rows = append(rows, []string{line})
+	}
+	return []string{"event"}, rows
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+func startTestBattle(t *testing.T) (*Config, *strings.Builder) {
+	t.Helper()
+
+	cfg := newAreaTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+
+	cfg.Collection.Add(trainer.Pokemon{Species: "caterpie", Nickname: "Cat", Level: 10, Experience: 1000, Moves: []string{"tackle"}})
+
+	for _, line := range []string{"goto viridian-forest-area", "walk"} {
+		if err := runCommand(cfg, line); err != nil {
+			t.Fatalf("%s failed: %v", line, err)
+		}
+	}
+	if cfg.Battle == nil || !strings.Contains(b.String(), "Go! Cat!\nCat ") || !strings.Contains(b.String(), "Moves: tackle (35/35)") {
+		t.Fatalf("expected a battle to start, got %q", b.String())
+	}
+	return cfg, &b
+}
+
+func TestBattle_FightUntilWon(t *testing.T) {
+	cfg, b := startTestBattle(t)
+
+	if err := runCommand(cfg, "walk"); !errors.Is(err, errInBattle) {
+		t.Errorf("expected walking during a battle to fail, got %v", err)
+	}
+	if err := runCommand(cfg, "item potion"); err == nil {
+		t.Error("expected a potion at full HP to be refused")
+	}
+
+	for turn := 0; turn < 20 && cfg.Battle != nil; turn++ {
+		b.Reset()
+		if err := runCommand(cfg, "fight tackle"); err != nil {
+			t.Fatalf("fight failed: %v", err)
+		}
+	}
+
+	if cfg.Battle != nil || cfg.WildEncounter != nil {
+		t.Fatal("expected the battle to end")
+	}
+	if !strings.Contains(b.String(), "Wild caterpie fainted!\nCat gained 16 Exp. Points!") {
+		t.Errorf("expected the wild caterpie to faint and Cat to gain experience, got %q", b.String())
+	}
+	if lead := cfg.Collection.Pokemon[0]; lead.Experience != 1016 || lead.EVs["hp"] != 1 {
+		t.Errorf("expected experience and effort values for the win, got %+v", lead)
+	}
+}
+
+func TestBattle_Run(t *testing.T) {
+	cfg, b := startTestBattle(t)
+
+	b.Reset()
+	if err := runCommand(cfg, "run"); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if b.String() != "Got away safely!\n" || cfg.Battle != nil || cfg.WildEncounter != nil {
+		t.Errorf("expected a faster Pokémon to escape, got %q", b.String())
+	}
+
+	if err := runCommand(cfg, "fight tackle"); err == nil {
+		t.Error("expected fight outside a battle to fail")
+	}
+}
+
+func TestBattle_CatchUsesRemainingHP(t *testing.T) {
+	cfg, _ := startTestBattle(t)
+
+	cfg.Battle.Wild.HP = 1
+	for attempt := 0; attempt < 50 && cfg.WildEncounter != nil; attempt++ {
+		if err := runCommand(cfg, "catch"); err != nil {
+			t.Fatalf("catch failed: %v", err)
+		}
+	}
+
+	if cfg.Battle != nil || len(cfg.Collection.Pokemon) != 2 {
+		t.Errorf("expected the weakened caterpie to be caught, got %+v", cfg.Collection.Pokemon)
+	}
+}
+
+func TestBattle_CollectionLocked(t *testing.T) {
+	cfg, _ := startTestBattle(t)
+
+	for _, line := range []string{"release cat", "deposit cat", "withdraw cat", "swap cat cat", "evolve", "use moon-stone cat", "trade cat"} {
+		if err := runCommand(cfg, line); !errors.Is(err, errInBattle) {
+			t.Errorf("expected %q during a battle to fail, got %v", line, err)
+		}
+	}
+	if len(cfg.Collection.Pokemon) != 1 || len(cfg.Collection.Party) != 1 {
+		t.Errorf("expected the battler to stay in the party, got %+v", cfg.Collection)
+	}
+}
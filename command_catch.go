@@ -3,49 +3,231 @@ package main
 import (
 	"errors"
 	"fmt"
-	"math/rand"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/capture"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
 )
 
-func commandCatch(cfg *Config, args ...string) error {
-	if len(args) != 1 {
-		return errors.New("you must provide exactly one Pokémon name to catch")
+type catchResult struct {
+	Pokemon string `json:"pokemon"`
+	Level   int    `json:"level"`
+	Caught  bool   `json:"caught"`
+	Shakes  int    `json:"shakes"`
+	Rules   string `json:"rules"`
+	Ball    string `json:"ball"`
+	ID      int    `json:"id,omitempty"`
+	NewDex  bool   `json:"new_pokedex_entry"`
+	SentTo  string `json:"sent_to,omitempty"`
+
+	ExperienceGain *experienceGain `json:"experience_gain,omitempty"`
+	Battle         *battleResult   `json:"battle,omitempty"`
+}
+
+func commandCatch(cfg *Config, args ...string) (result, error) {
+	args, flags, err := parseFlags(args, map[string]bool{"ball": true, "status": true})
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 1 {
+		return nil, errors.New("you can only catch one Pokémon at a time")
+	}
+
+	wild := cfg.WildEncounter
+	if wild == nil {
+		return nil, errors.New("there is no wild Pokémon to catch; use walk, fish or surf to find one")
+	}
+	if len(args) == 1 && args[0] != wild.Pokemon {
+		return nil, fmt.Errorf("the wild Pokémon in front of you is %s, not %s", wild.Pokemon, args[0])
+	}
+
+	ballName := "poke-ball"
+	if name, ok := flags["ball"]; ok {
+		ballName = strings.ToLower(name)
+	}
+	ball, err := capture.LookupBall(ballName)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Inventory.Count(ball.Name) == 0 {
+		return nil, fmt.Errorf("you don't have any %s; buy some at the mart", ball.Name)
+	}
+	// Battles do not inflict status conditions, so the player says which
+	// one the wild Pokémon has.
+	status := capture.StatusNone
+	if name, ok := flags["status"]; ok {
+		if status, err = capture.ParseStatus(strings.ToLower(name)); err != nil {
+			return nil, err
+		}
+	}
+
+	pokemonData, err := cfg.PokeapiClient.GetPokemonDetails(wild.Pokemon)
+	if err != nil {
+		return nil, err
 	}
-	pokemonName := args[0]
 
-	if _, caught := cfg.Pokedex[pokemonName]; caught {
-		fmt.Printf("%s is already in your Pokedex!\n", pokemonName)
-		return nil
+	species, err := cfg.PokeapiClient.GetPokemonSpecies(pokemonData.Species.Name)
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
+	catchRule, err := capture.Lookup(cfg.CatchRules)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a battle the wild Pokémon is at full health.
+	maxHP, currentHP := 1, 1
+	if cfg.Battle != nil {
+		if cfg.Battle.Player.Fainted() {
+			return nil, fmt.Errorf("%s has fainted; switch to another Pokémon", cfg.Battle.Player.Name)
+		}
+		maxHP, currentHP = cfg.Battle.Wild.MaxHP(), cfg.Battle.Wild.HP
+	}
+
+	// Everything a successful catch needs is looked up before the ball is
+	// thrown, so a failed lookup never costs a ball or a roll.
+	curve, err := growthCurve(cfg, pokemonData)
+	if err != nil {
+		return nil, err
+	}
+	nature, err := randomNature(cfg)
+	if err != nil {
+		return nil, err
+	}
+	group, err := versionGroup(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	pokemonData, err := cfg.PokeapiClient.GetPokemonDetails(pokemonName)
+	// Catching a Pokémon rewards whoever is battling, or else the party
+	// lead, as if it had been defeated.
+	recipient := partyLead(cfg)
+	if cfg.Battle != nil {
+		recipient, _ = cfg.Collection.Get(cfg.Battle.ActiveID)
+	}
+	award, err := prepareExperience(cfg, recipient)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if err := cfg.Inventory.Remove(ball.Name, 1); err != nil {
+		return nil, err
+	}
+	cfg.Pokedex.MarkSeen(species.Name)
+	cfg.progressf("Throwing a %s at %s...\n", ball.Name, wild.Pokemon)
+
+	attempt := capture.Throw(catchRule, ball, capture.Input{
+		CaptureRate: species.CaptureRate,
+		MaxHP:       maxHP,
+		CurrentHP:   currentHP,
+		Status:      status,
+	}, cfg.Rand.Intn)
+
+	res := catchResult{
+		Pokemon: pokemonData.Name,
+		Level:   wild.Level,
+		Caught:  attempt.Caught,
+		Shakes:  attempt.Shakes,
+		Rules:   catchRule.Name(),
+		Ball:    ball.Name,
+	}
+	if !attempt.Caught {
+		if cfg.Battle != nil {
+			turn, err := cfg.Battle.WildTurn(nil)
+			if err != nil {
+				return nil, err
+			}
+			res.Battle, err = finishTurn(cfg, turn)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+
+	individual := trainer.Pokemon{
+		Species:    pokemonData.Name,
+		Level:      wild.Level,
+		Experience: curve.Experience(wild.Level),
+		IVs:        trainer.RandomIVs(cfg.Rand.Intn),
+		Nature:     nature,
+		Moves:      startingMoves(pokemonData, group, wild.Level),
+		Ability:    randomAbility(cfg, pokemonData),
+		CaughtAt:   time.Now().UTC(),
+		Location:   cfg.CurrentArea,
 	}
 
-	const maxRollValue = 500
-	const minCatchScore = 50
-	const maxCatchScore = maxRollValue - 50
+	if cfg.Battle != nil {
+		individual.IVs = cfg.Battle.WildIndividual.IVs
+		individual.Moves = cfg.Battle.WildIndividual.Moves
+	}
+	res.ExperienceGain = award.apply(cfg, pokemonData, wild.Level)
+
+	endBattle(cfg)
+	res.NewDex = !cfg.Pokedex.HasCaught(species.Name)
+	cfg.Pokedex.MarkCaught(species.Name)
 
-	catchScore := maxRollValue - pokemonData.BaseExperience
+	caught, place := cfg.Collection.Add(individual)
+	res.ID = caught.ID
+	res.SentTo = place.String()
+
+	return res, nil
+}
+
+func (r catchResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprintln(w, describeShakes(r.Shakes))
+
+	if !r.Caught {
+		fmt.Fprintf(w, "%s broke free!\n", p.Bad(r.Pokemon))
+		if r.Battle != nil {
+			r.Battle.writeText(w, p)
+		}
+		return
+	}
 
-	if catchScore < minCatchScore {
-		catchScore = minCatchScore
+	fmt.Fprintf(w, "%s was caught!\n", p.Good(r.Pokemon))
+	r.ExperienceGain.writeText(w, p)
+	if r.NewDex {
+		fmt.Fprintf(w, "%s's data was added to the Pokedex.\n", r.Pokemon)
 	}
-	if catchScore > maxCatchScore {
-		catchScore = maxCatchScore
+	fmt.Fprintf(w, "%s (Lv. %d) is now #%d in your collection.\n", r.Pokemon, r.Level, r.ID)
+	if r.SentTo == "party" {
+		fmt.Fprintf(w, "%s joined your party.\n", r.Pokemon)
+	} else if r.SentTo != "" {
+		fmt.Fprintf(w, "Your party is full, so %s was sent to %s.\n", r.Pokemon, r.SentTo)
+	}
+}
+
+// describeShakes narrates the ball wobbling, e.g. "The ball shook
+// 1..2..3 times."
+func describeShakes(shakes int) string {
+	if shakes == 0 {
+		return "The ball didn't shake."
 	}
 
-	roll := rand.Intn(maxRollValue)
+	counts := make([]string, shakes)
+	for i := range counts {
+		counts[i] = strconv.Itoa(i + 1)
+	}
 
-	if roll < catchScore {
-		fmt.Printf("%s was caught!\n", pokemonData.Name)
-		cfg.Pokedex[pokemonData.Name] = pokemonData
-		fmt.Printf("%s added to Pokedex.\n", pokemonData.Name)
-	} else {
-		fmt.Printf("%s escaped!\n", pokemonData.Name)
+	unit := "times"
+	if shakes == 1 {
+		unit = "time"
 	}
+	return fmt.Sprintf("The ball shook %s %s.", strings.Join(counts, ".."), unit)
+}
 
-	return nil
+func (r catchResult) table() ([]string, [][]string) {
+	outcome := "broke free"
+	id := ""
+	if r.Caught {
+		outcome = "caught"
+		id = strconv.Itoa(r.ID)
+	}
+	return []string{"pokemon", "level", "result", "shakes", "id", "sent_to"}, [][]string{{r.Pokemon, strconv.Itoa(r.Level), outcome, strconv.Itoa(r.Shakes), id, r.SentTo}}
 }
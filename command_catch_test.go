@@ -0,0 +1,172 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+func TestDescribeShakes(t *testing.T) {
+	cases := map[int]string{
+		0: "The ball didn't shake.",
+		1: "The ball shook 1 time.",
+		3: "The ball shook 1..2..3 times.",
+	}
+
+	for shakes, expected := range cases {
+		if got := describeShakes(shakes); got != expected {
+			t.Errorf("describeShakes(%d) = %q, expected %q", shakes, got, expected)
+		}
+	}
+}
+
+func TestCommandCatch_CatchesEncounter(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+
+	if err := runCommand(cfg, "goto viridian-forest-area"); err != nil {
+		t.Fatalf("goto failed: %v", err)
+	}
+	if err := runCommand(cfg, "walk"); err != nil {
+		t.Fatalf("walk failed: %v", err)
+	}
+
+	cfg.Inventory.Add("master-ball", 1)
+	b.Reset()
+	if err := runCommand(cfg, "catch --ball master-ball"); err != nil {
+		t.Fatalf("catch failed: %v", err)
+	}
+
+	if !strings.Contains(b.String(), "The ball shook 1..2..3 times.") || !strings.Contains(b.String(), "caterpie was caught!") {
+		t.Errorf("unexpected catch output %q", b.String())
+	}
+	if !cfg.Pokedex.HasCaught("caterpie") || cfg.WildEncounter != nil {
+		t.Errorf("expected caterpie to be caught in the Pokedex and the encounter to end, got %+v", cfg.WildEncounter)
+	}
+	if len(cfg.Collection.Pokemon) != 1 || cfg.Collection.Pokemon[0].Level != 3 || cfg.Collection.Pokemon[0].Location != "viridian-forest-area" {
+		t.Errorf("expected a level 3 caterpie from Viridian Forest in the collection, got %+v", cfg.Collection.Pokemon)
+	}
+}
+
+func TestCommandCatch_SeededReplay(t *testing.T) {
+	play := func() string {
+		cfg := newAreaTestConfig(t)
+		var b strings.Builder
+		cfg.Out = &b
+
+		for _, line := range []string{"seed 42", "goto viridian-forest-area", "walk", "catch", "catch", "catch"} {
+			if err := runCommand(cfg, line); err != nil {
+				b.WriteString(err.Error() + "\n")
+			}
+		}
+		return b.String()
+	}
+
+	first, second := play(), play()
+	if first != second {
+		t.Errorf("expected identical sessions with the same seed:\n%s\nvs\n%s", first, second)
+	}
+}
+
+func TestCommandCatch_UnknownRules(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	cfg.CatchRules = "gen99"
+
+	if err := runCommand(cfg, "goto viridian-forest-area"); err != nil {
+		t.Fatalf("goto failed: %v", err)
+	}
+	if err := runCommand(cfg, "walk"); err != nil {
+		t.Fatalf("walk failed: %v", err)
+	}
+
+	if err := runCommand(cfg, "catch"); err == nil {
+		t.Error("expected catch to fail with unknown rules")
+	}
+}
+
+func TestCommandCatch_AwardsExperience(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+
+	cfg.Collection.Add(trainer.Pokemon{Species: "caterpie", Level: 3, Experience: 60})
+
+	if err := runCommand(cfg, "goto viridian-forest-area"); err != nil {
+		t.Fatalf("goto failed: %v", err)
+	}
+	if err := runCommand(cfg, "walk"); err != nil {
+		t.Fatalf("walk failed: %v", err)
+	}
+	cfg.Inventory.Add("master-ball", 1)
+	b.Reset()
+	if err := runCommand(cfg, "catch --ball master-ball"); err != nil {
+		t.Fatalf("catch failed: %v", err)
+	}
+
+	// 39 base experience at level 3 yields 16, taking the lead from 60 to
+	// 76, past the 64 needed for level 4.
+	if !strings.Contains(b.String(), "caterpie gained 16 Exp. Points!\ncaterpie grew to level 4!\n") {
+		t.Errorf("expected the lead to gain experience and level up, got %q", b.String())
+	}
+	if cfg.WildEncounter != nil {
+		t.Errorf("expected the encounter to end with the catch, got %+v", cfg.WildEncounter)
+	}
+	lead := cfg.Collection.Pokemon[0]
+	if lead.Level != 4 || lead.Experience != 76 {
+		t.Errorf("expected the lead at level 4 with 76 experience, got %+v", lead)
+	}
+	if caught := cfg.Collection.Pokemon[1]; caught.Experience != 27 {
+		t.Errorf("expected the new catch to start with 27 experience, got %d", caught.Experience)
+	}
+}
+
+func TestCommandCatch_LookupFailureKeepsBall(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+
+	if err := runCommand(cfg, "goto viridian-forest-area"); err != nil {
+		t.Fatalf("goto failed: %v", err)
+	}
+	if err := runCommand(cfg, "walk"); err != nil {
+		t.Fatalf("walk failed: %v", err)
+	}
+	cfg.GameVersion = "missing-version"
+	cfg.Inventory.Add("master-ball", 1)
+
+	if err := runCommand(cfg, "catch --ball master-ball"); err == nil {
+		t.Fatal("expected catch to fail when the version can't be looked up")
+	}
+	if cfg.Inventory.Count("master-ball") != 1 || cfg.WildEncounter == nil || len(cfg.Collection.Pokemon) != 0 {
+		t.Errorf("expected the ball and the encounter to be kept, got %d master balls and encounter %v", cfg.Inventory.Count("master-ball"), cfg.WildEncounter)
+	}
+}
+
+func TestCommandCatch_Status(t *testing.T) {
+	throw := func(flags string) (*Config, error) {
+		cfg := newAreaTestConfig(t)
+		// Reseeding after the walk fixes the throw's roll, which misses a
+		// healthy caterpie but catches a sleeping one.
+		for _, line := range []string{"goto viridian-forest-area", "walk", "seed 5"} {
+			if err := runCommand(cfg, line); err != nil {
+				t.Fatalf("%s failed: %v", line, err)
+			}
+		}
+		return cfg, runCommand(cfg, "catch"+flags)
+	}
+
+	if cfg, err := throw(""); err != nil || cfg.WildEncounter == nil {
+		t.Fatalf("expected the caterpie to break free without a status, got %v", err)
+	}
+	if cfg, err := throw(" --status sleep"); err != nil || cfg.WildEncounter != nil {
+		t.Errorf("expected sleep to make the same throw catch, got %v", err)
+	}
+
+	cfg, err := throw(" --status confused")
+	if err == nil || !strings.Contains(err.Error(), "unknown status 'confused'") {
+		t.Errorf("expected an unknown status to be rejected, got %v", err)
+	}
+	if cfg.Inventory.Count("poke-ball") != 5 {
+		t.Errorf("expected a rejected status to keep the ball, got %d", cfg.Inventory.Count("poke-ball"))
+	}
+}
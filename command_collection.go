@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+type collectionResult struct {
+	Pokemon []trainer.Pokemon `json:"pokemon"`
+}
+
+func commandCollection(cfg *Config, args ...string) (result, error) {
+	if len(args) > 0 {
+		return nil, errors.New("collection command does not take any arguments")
+	}
+
+	pokemon := make([]trainer.Pokemon, len(cfg.Collection.Pokemon))
+	copy(pokemon, cfg.Collection.Pokemon)
+
+	return collectionResult{Pokemon: pokemon}, nil
+}
+
+func commandRename(cfg *Config, args ...string) (result, error) {
+	if len(args) != 2 {
+		return nil, errors.New("usage: rename <id|nickname|species> <nickname>")
+	}
+
+	individual, err := cfg.Collection.Find(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	nickname := args[1]
+	if nickname == "-" {
+		individual.Nickname = ""
+		return messageResult{Message: fmt.Sprintf("#%d is called %s again.", individual.ID, individual.Species)}, nil
+	}
+
+	if err := trainer.ValidateNickname(nickname); err != nil {
+		return nil, err
+	}
+	if other, err := cfg.Collection.Find(nickname); err == nil && other.ID != individual.ID && other.Nickname != "" {
+		return nil, fmt.Errorf("#%d is already called %s", other.ID, other.Nickname)
+	}
+
+	oldName := individual.Name()
+	individual.Nickname = nickname
+
+	return messageResult{Message: fmt.Sprintf("%s (#%d) is now known as %s.", oldName, individual.ID, nickname)}, nil
+}
+
+func commandRelease(cfg *Config, args ...string) (result, error) {
+	if len(args) != 1 {
+		return nil, errors.New("you must provide exactly one Pokémon to release")
+	}
+	if cfg.Battle != nil {
+		return nil, errInBattle
+	}
+
+	individual, err := cfg.Collection.Find(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	released, err := cfg.Collection.Release(individual.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return messageResult{Message: fmt.Sprintf("%s (#%d) was released. Bye, %s!", released.Name(), released.ID, released.Name())}, nil
+}
+
+func (r collectionResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprintln(w, p.Header("Your Pokémon:"))
+
+	if len(r.Pokemon) == 0 {
+		fmt.Fprintln(w, " (none caught yet)")
+		return
+	}
+
+	for _, pokemon := range r.Pokemon {
+		name := pokemon.Species
+		if pokemon.Nickname != "" {
+			name = fmt.Sprintf("%s (%s)", p.Bold(pokemon.Nickname), pokemon.Species)
+		}
+		fmt.Fprintf(w, " #%-3d %s Lv. %d\n", pokemon.ID, name, pokemon.Level)
+	}
+}
+
+func (r collectionResult) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Pokemon))
+	for _, pokemon := range r.Pokemon {
+		rows = append(rows, []string{
+			strconv.Itoa(pokemon.ID),
+			pokemon.Species,
+			pokemon.Nickname,
+			strconv.Itoa(pokemon.Level),
+			pokemon.CaughtAt.Format(time.RFC3339),
+			pokemon.Location,
+		})
+	}
+	return []string{"id", "species", "nickname", "level", "caught_at", "location"}, rows
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/config"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+func TestCollectionCommands(t *testing.T) {
+	cfg := newConfig(config.Defaults())
+	var b strings.Builder
+	cfg.Out = &b
+
+	cfg.Collection.Add(trainer.Pokemon{Species: "pikachu", Level: 5})
+	cfg.Collection.Add(trainer.Pokemon{Species: "pikachu", Level: 9})
+
+	if err := runCommand(cfg, "rename pikachu Sparky"); err == nil {
+		t.Error("expected renaming an ambiguous species to fail")
+	}
+
+	if err := runCommand(cfg, "rename 2 Sparky"); err != nil {
+		t.Fatalf("rename failed: %v", err)
+	}
+	if cfg.Collection.Pokemon[1].Nickname != "Sparky" {
+		t.Errorf("expected nickname to keep its case, got '%s'", cfg.Collection.Pokemon[1].Nickname)
+	}
+
+	if err := runCommand(cfg, "rename 1 sparky"); err == nil {
+		t.Error("expected a duplicate nickname to be refused")
+	}
+
+	b.Reset()
+	if err := runCommand(cfg, "collection"); err != nil {
+		t.Fatalf("collection failed: %v", err)
+	}
+	expected := "Your Pokémon:\n #1   pikachu Lv. 5\n #2   Sparky (pikachu) Lv. 9\n"
+	if b.String() != expected {
+		t.Errorf("expected collection output %q, got %q", expected, b.String())
+	}
+
+	if err := runCommand(cfg, "release sparky"); err != nil {
+		t.Fatalf("release failed: %v", err)
+	}
+	if len(cfg.Collection.Pokemon) != 1 || cfg.Collection.Pokemon[0].ID != 1 {
+		t.Errorf("expected only #1 to remain, got %+v", cfg.Collection.Pokemon)
+	}
+}
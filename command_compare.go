@@ -0,0 +1,207 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+type comparedPokemon struct {
+	Name      string        `json:"name"`
+	Types     []string      `json:"types"`
+	Abilities []string      `json:"abilities"`
+	HeightM   float64       `json:"height_m"`
+	WeightKg  float64       `json:"weight_kg"`
+	Stats     trainer.Stats `json:"stats"`
+	Total     int           `json:"total"`
+}
+
+type compareResult struct {
+	Pokemon []comparedPokemon `json:"pokemon"`
+}
+
+// commandCompare lines up the base stats and physical details of two or
+// more Pokémon. Caught Pokémon can be referred to by ID or nickname.
+func commandCompare(cfg *Config, args ...string) (result, error) {
+	if len(args) < 2 {
+		return nil, errors.New("you must provide at least two Pokémon to compare")
+	}
+
+	res := compareResult{Pokemon: make([]comparedPokemon, 0, len(args))}
+	for _, ref := range args {
+		species := ref
+		if individual, err := cfg.Collection.Find(ref); err == nil {
+			species = individual.Species
+		}
+		pokemon, err := cfg.PokeapiClient.GetPokemonDetails(species)
+		if err != nil {
+			return nil, err
+		}
+
+		compared := comparedPokemon{
+			Name:      pokemon.Name,
+			Types:     make([]string, 0, len(pokemon.Types)),
+			Abilities: make([]string, 0, len(pokemon.Abilities)),
+			// PokeAPI reports height in decimetres and weight in hectograms.
+			HeightM:  float64(pokemon.Height) / 10,
+			WeightKg: float64(pokemon.Weight) / 10,
+			Stats:    baseStats(pokemon),
+		}
+		for _, typeEntry := range pokemon.Types {
+			compared.Types = append(compared.Types, typeEntry.Type.Name)
+		}
+		for _, abilityEntry := range pokemon.Abilities {
+			name := abilityEntry.Ability.Name
+			if abilityEntry.IsHidden {
+				name += " (hidden)"
+			}
+			compared.Abilities = append(compared.Abilities, name)
+		}
+		for _, stat := range trainer.StatNames {
+			compared.Total += compared.Stats[stat]
+		}
+		res.Pokemon = append(res.Pokemon, compared)
+	}
+	return res, nil
+}
+
+// statRows returns one row per base stat plus the total, in display order.
+func (r compareResult) statRows() ([]string, [][]int) {
+	labels := append(append([]string{}, trainer.StatNames...), "total")
+	rows := make([][]int, len(labels))
+	for i, label := range labels {
+		for _, pokemon := range r.Pokemon {
+			if label == "total" {
+				rows[i] = append(rows[i], pokemon.Total)
+			} else {
+				rows[i] = append(rows[i], pokemon.Stats[label])
+			}
+		}
+	}
+	return labels, rows
+}
+
+// highest reports which values in a row are the row's maximum. A row where
+// every value is equal has no highlight.
+func highest(values []int) []bool {
+	best := values[0]
+	tied := true
+	for _, value := range values[1:] {
+		if value != best {
+			tied = false
+		}
+		best = max(best, value)
+	}
+
+	marks := make([]bool, len(values))
+	for i, value := range values {
+		marks[i] = !tied && value == best
+	}
+	return marks
+}
+
+func (r compareResult) writeText(w io.Writer, p style.Palette) {
+	labels, statRows := r.statRows()
+
+	type cell struct {
+		text      string
+		highlight bool
+		types     []string
+	}
+	var rows [][]cell
+	for i, values := range statRows {
+		marks := highest(values)
+		row := []cell{{text: labels[i]}}
+		for j, value := range values {
+			text := strconv.Itoa(value)
+			// Without color, mark the highest value so it still stands out.
+			if marks[j] && !p.Enabled() {
+				text += "*"
+			}
+			row = append(row, cell{text: text, highlight: marks[j]})
+		}
+		rows = append(rows, row)
+	}
+
+	details := [][]cell{{{text: "types"}}, {{text: "abilities"}}, {{text: "height"}}, {{text: "weight"}}}
+	for _, pokemon := range r.Pokemon {
+		details[0] = append(details[0], cell{text: strings.Join(pokemon.Types, "/"), types: pokemon.Types})
+		details[1] = append(details[1], cell{text: strings.Join(pokemon.Abilities, ", ")})
+		details[2] = append(details[2], cell{text: fmt.Sprintf("%.1f m", pokemon.HeightM)})
+		details[3] = append(details[3], cell{text: fmt.Sprintf("%.1f kg", pokemon.WeightKg)})
+	}
+	rows = append(rows, details...)
+
+	header := []cell{{text: ""}}
+	for _, pokemon := range r.Pokemon {
+		header = append(header, cell{text: pokemon.Name})
+	}
+	rows = append([][]cell{header}, rows...)
+
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for i, c := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c.text))
+		}
+	}
+
+	for rowIndex, row := range rows {
+		var line strings.Builder
+		for i, c := range row {
+			padding := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c.text))
+			text := c.text
+			switch {
+			case rowIndex == 0 || i == 0:
+				text = p.Header(text)
+			case c.highlight:
+				text = p.Good(text)
+			case c.types != nil:
+				styled := make([]string, len(c.types))
+				for k, typeName := range c.types {
+					styled[k] = p.Type(typeName)
+				}
+				text = strings.Join(styled, "/")
+			}
+			if i > 0 {
+				line.WriteString("  ")
+			}
+			line.WriteString(text)
+			if i < len(row)-1 {
+				line.WriteString(padding)
+			}
+		}
+		fmt.Fprintln(w, strings.TrimRight(line.String(), " "))
+	}
+}
+
+func (r compareResult) table() ([]string, [][]string) {
+	headers := []string{"field"}
+	for _, pokemon := range r.Pokemon {
+		headers = append(headers, pokemon.Name)
+	}
+
+	labels, statRows := r.statRows()
+	rows := make([][]string, 0, len(labels)+4)
+	for i, values := range statRows {
+		row := []string{labels[i]}
+		for _, value := range values {
+			row = append(row, strconv.Itoa(value))
+		}
+		rows = append(rows, row)
+	}
+
+	types, abilities, height, weight := []string{"types"}, []string{"abilities"}, []string{"height_m"}, []string{"weight_kg"}
+	for _, pokemon := range r.Pokemon {
+		types = append(types, strings.Join(pokemon.Types, ", "))
+		abilities = append(abilities, strings.Join(pokemon.Abilities, ", "))
+		height = append(height, strconv.FormatFloat(pokemon.HeightM, 'f', 1, 64))
+		weight = append(weight, strconv.FormatFloat(pokemon.WeightKg, 'f', 1, 64))
+	}
+	return headers, append(rows, types, abilities, height, weight)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+func TestCommandCompare(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+	cfg.Collection.Add(trainer.Pokemon{Species: "metapod", Nickname: "Shell", Level: 7})
+
+	if err := runCommand(cfg, "compare caterpie Shell"); err != nil {
+		t.Fatalf("compare failed: %v", err)
+	}
+	expected := "                 caterpie                        metapod\n" +
+		"hp               45                              50*\n" +
+		"attack           30*                             20\n" +
+		"defense          35                              55*\n" +
+		"special-attack   20                              25*\n" +
+		"special-defense  20                              25*\n" +
+		"speed            45*                             30\n" +
+		"total            195                             205*\n" +
+		"types            bug                             bug\n" +
+		"abilities        shield-dust, run-away (hidden)  shed-skin\n" +
+		"height           0.3 m                           0.7 m\n" +
+		"weight           2.9 kg                          9.9 kg\n"
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+
+	b.Reset()
+	if err := runCommand(cfg, "compare caterpie metapod --output table"); err != nil {
+		t.Fatalf("compare failed: %v", err)
+	}
+	if !strings.Contains(b.String(), "weight_kg        2.9") {
+		t.Errorf("unexpected table output:\n%s", b.String())
+	}
+
+	for _, line := range []string{"compare caterpie", "compare caterpie missingno"} {
+		if err := runCommand(cfg, line); err == nil {
+			t.Errorf("expected %q to fail", line)
+		}
+	}
+}
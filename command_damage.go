@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GrahamZiervogel/pokedex/internal/battle"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+type damageResult struct {
+	Attacker      string  `json:"attacker"`
+	AttackerLevel int     `json:"attacker_level"`
+	Move          string  `json:"move"`
+	MoveType      string  `json:"move_type"`
+	Category      string  `json:"category"`
+	Power         int     `json:"power"`
+	Defender      string  `json:"defender"`
+	DefenderLevel int     `json:"defender_level"`
+	DefenderHP    int     `json:"defender_hp"`
+	STAB          bool    `json:"stab"`
+	Effectiveness float64 `json:"effectiveness"`
+	Min           int     `json:"min"`
+	Max           int     `json:"max"`
+	CriticalMin   int     `json:"critical_min"`
+	CriticalMax   int     `json:"critical_max"`
+}
+
+// commandDamage computes the damage range of one move between two Pokémon,
+// caught or not. Uncaught species use the same defaults as stats.
+func commandDamage(cfg *Config, args ...string) (result, error) {
+	positional, flags, err := parseFlags(args, map[string]bool{
+		"level":           true,
+		"attacker-level":  true,
+		"defender-level":  true,
+		"attacker-nature": true,
+		"defender-nature": true,
+		"attack-stage":    true,
+		"defense-stage":   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 3 {
+		return nil, errors.New("usage: damage <attacker> <move> <defender> [--level N] [--attacker-level N] [--defender-level N] " +
+			"[--attacker-nature X] [--defender-nature X] [--attack-stage N] [--defense-stage N]")
+	}
+
+	attacker, err := statSubject(cfg, positional[0])
+	if err != nil {
+		return nil, err
+	}
+	defender, err := statSubject(cfg, positional[2])
+	if err != nil {
+		return nil, err
+	}
+	levelOptions := []struct {
+		flag    string
+		subject *trainer.Pokemon
+	}{
+		{"level", &attacker}, {"level", &defender},
+		{"attacker-level", &attacker}, {"defender-level", &defender},
+	}
+	for _, option := range levelOptions {
+		if value, ok := flags[option.flag]; ok {
+			if option.subject.Level, err = parseLevel(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if value, ok := flags["attacker-nature"]; ok {
+		if attacker.Nature, err = lookupNature(cfg, value); err != nil {
+			return nil, err
+		}
+	}
+	if value, ok := flags["defender-nature"]; ok {
+		if defender.Nature, err = lookupNature(cfg, value); err != nil {
+			return nil, err
+		}
+	}
+	attackStage, err := parseStage(flags["attack-stage"])
+	if err != nil {
+		return nil, err
+	}
+	defenseStage, err := parseStage(flags["defense-stage"])
+	if err != nil {
+		return nil, err
+	}
+
+	attackerData, err := cfg.PokeapiClient.GetPokemonDetails(attacker.Species)
+	if err != nil {
+		return nil, err
+	}
+	defenderData, err := cfg.PokeapiClient.GetPokemonDetails(defender.Species)
+	if err != nil {
+		return nil, err
+	}
+	move, err := battleMove(cfg, positional[1])
+	if err != nil {
+		return nil, err
+	}
+	if move.Category == battle.CategoryStatus || move.Power == 0 {
+		return nil, fmt.Errorf("%s does not deal damage", move.Name)
+	}
+	moveType, err := cfg.PokeapiClient.GetType(move.Type)
+	if err != nil {
+		return nil, err
+	}
+	chart := battle.Chart{}
+	chart.Add(moveType)
+
+	attackerStats := attacker.ActualStats(baseStats(attackerData))
+	defenderStats := defender.ActualStats(baseStats(defenderData))
+	attackStat, defenseStat := "attack", "defense"
+	if move.Category == battle.CategorySpecial {
+		attackStat, defenseStat = "special-attack", "special-defense"
+	}
+
+	var attackerTypes, defenderTypes []string
+	for _, typeEntry := range attackerData.Types {
+		attackerTypes = append(attackerTypes, typeEntry.Type.Name)
+	}
+	for _, typeEntry := range defenderData.Types {
+		defenderTypes = append(defenderTypes, typeEntry.Type.Name)
+	}
+
+	in := battle.DamageInput{
+		Level:         attacker.Level,
+		Power:         move.Power,
+		Attack:        battle.ApplyStage(attackerStats[attackStat], attackStage),
+		Defense:       battle.ApplyStage(defenderStats[defenseStat], defenseStage),
+		STAB:          slices.Contains(attackerTypes, move.Type),
+		Effectiveness: chart.Effectiveness(move.Type, defenderTypes),
+	}
+
+	res := damageResult{
+		Attacker:      attackerData.Name,
+		AttackerLevel: attacker.Level,
+		Move:          move.Name,
+		MoveType:      move.Type,
+		Category:      move.Category,
+		Power:         move.Power,
+		Defender:      defenderData.Name,
+		DefenderLevel: defender.Level,
+		DefenderHP:    defenderStats["hp"],
+		STAB:          in.STAB,
+		Effectiveness: in.Effectiveness,
+	}
+	res.Min, res.Max = battle.DamageRange(in)
+
+	critAttackStage, critDefenseStage := battle.CriticalStages(attackStage, defenseStage)
+	in.Attack = battle.ApplyStage(attackerStats[attackStat], critAttackStage)
+	in.Defense = battle.ApplyStage(defenderStats[defenseStat], critDefenseStage)
+	in.Critical = true
+	res.CriticalMin, res.CriticalMax = battle.DamageRange(in)
+
+	return res, nil
+}
+
+func parseStage(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	stage, err := strconv.Atoi(value)
+	if err != nil || stage < battle.MinStage || stage > battle.MaxStage {
+		return 0, fmt.Errorf("stat stages must be a number from %d to %d", battle.MinStage, battle.MaxStage)
+	}
+	return stage, nil
+}
+
+func (r damageResult) percent(damage int) float64 {
+	return float64(damage) * 100 / float64(max(r.DefenderHP, 1))
+}
+
+func (r damageResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprintf(w, "%s (Lv. %d) used %s on %s (Lv. %d)\n", p.Bold(r.Attacker), r.AttackerLevel, r.Move, p.Bold(r.Defender), r.DefenderLevel)
+	fmt.Fprintf(w, "%s %s, %s, %d power\n", p.Header("Move:"), p.Type(r.MoveType), r.Category, r.Power)
+	if r.Effectiveness == 0 {
+		fmt.Fprintf(w, "It doesn't affect %s.\n", r.Defender)
+		return
+	}
+
+	fmt.Fprintf(w, "%s %d-%d (%.1f%%-%.1f%% of %d HP)\n", p.Header("Damage:"), r.Min, r.Max, r.percent(r.Min), r.percent(r.Max), r.DefenderHP)
+	fmt.Fprintf(w, "%s %d-%d (%.1f%%-%.1f%%)\n", p.Header("Critical hit:"), r.CriticalMin, r.CriticalMax, r.percent(r.CriticalMin), r.percent(r.CriticalMax))
+
+	var notes []string
+	if r.STAB {
+		notes = append(notes, "STAB")
+	}
+	switch {
+	case r.Effectiveness > 1:
+		notes = append(notes, fmt.Sprintf("super effective (%gx)", r.Effectiveness))
+	case r.Effectiveness < 1:
+		notes = append(notes, fmt.Sprintf("not very effective (%gx)", r.Effectiveness))
+	}
+	if len(notes) > 0 {
+		fmt.Fprintln(w, strings.Join(notes, ", "))
+	}
+}
+
+func (r damageResult) table() ([]string, [][]string) {
+	return []string{"attacker", "move", "defender", "min", "max", "critical_min", "critical_max", "defender_hp"}, [][]string{{
+		r.Attacker, r.Move, r.Defender,
+		strconv.Itoa(r.Min), strconv.Itoa(r.Max),
+		strconv.Itoa(r.CriticalMin), strconv.Itoa(r.CriticalMax),
+		strconv.Itoa(r.DefenderHP),
+	}}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandDamage(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+
+	if err := runCommand(cfg, "damage caterpie tackle caterpie"); err != nil {
+		t.Fatalf("damage failed: %v", err)
+	}
+	expected := "caterpie (Lv. 50) used tackle on caterpie (Lv. 50)\n" +
+		"Move: normal, physical, 40 power\n" +
+		"Damage: 15-18 (12.5%-15.0% of 120 HP)\n" +
+		"Critical hit: 22-27 (18.3%-22.5%)\n"
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+
+	b.Reset()
+	if err := runCommand(cfg, "damage caterpie tackle caterpie --attacker-level 100 --attacker-nature adamant --attack-stage 2 --defense-stage -1"); err != nil {
+		t.Fatalf("damage failed: %v", err)
+	}
+	if !strings.Contains(b.String(), "Damage: 168-198 (140.0%-165.0% of 120 HP)\n") {
+		t.Errorf("unexpected boosted damage:\n%s", b.String())
+	}
+
+	err := runCommand(cfg, "damage caterpie string-shot caterpie")
+	if err == nil || err.Error() != "string-shot does not deal damage" {
+		t.Errorf("expected a status move to be rejected, got %v", err)
+	}
+
+	for _, line := range []string{
+		"damage caterpie tackle caterpie --attack-stage 7",
+		"damage caterpie tackle caterpie --level 101",
+		"damage caterpie tackle",
+		"damage 7 tackle caterpie",
+		"damage caterpie tackle #7",
+	} {
+		if err := runCommand(cfg, line); err == nil {
+			t.Errorf("expected %q to fail", line)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GrahamZiervogel/pokedex/internal/encounter"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+)
+
+var fishingRods = map[string]string{
+	"old":   "old-rod",
+	"good":  "good-rod",
+	"super": "super-rod",
+}
+
+type encounterResult struct {
+	Area    string `json:"area"`
+	Method  string `json:"method"`
+	Version string `json:"version"`
+	Found   bool   `json:"found"`
+	Pokemon string `json:"pokemon,omitempty"`
+	Level   int    `json:"level,omitempty"`
+
+	Battle *battleStatus `json:"battle,omitempty"`
+}
+
+func commandWalk(cfg *Config, args ...string) (result, error) {
+	if len(args) > 0 {
+		return nil, errors.New("walk command does not take any arguments")
+	}
+	return rollEncounter(cfg, "walk")
+}
+
+func commandFish(cfg *Config, args ...string) (result, error) {
+	if len(args) > 1 {
+		return nil, errors.New("usage: fish [old|good|super]")
+	}
+
+	rod := "old"
+	if len(args) == 1 {
+		rod = strings.TrimSuffix(args[0], "-rod")
+	}
+	method, ok := fishingRods[rod]
+	if !ok {
+		return nil, fmt.Errorf("unknown rod '%s' (expected old, good or super)", args[0])
+	}
+
+	return rollEncounter(cfg, method)
+}
+
+func commandSurf(cfg *Config, args ...string) (result, error) {
+	if len(args) > 0 {
+		return nil, errors.New("surf command does not take any arguments")
+	}
+	return rollEncounter(cfg, "surf")
+}
+
+// rollEncounter looks for a wild Pokémon in the current area. A successful
+// roll replaces any previous wild encounter as the target for catch.
+func rollEncounter(cfg *Config, method string) (result, error) {
+	if cfg.CurrentArea == "" {
+		return nil, errors.New("you are not in any location area; use 'goto <location_area_name>' first")
+	}
+	if cfg.Battle != nil {
+		return nil, errInBattle
+	}
+
+	areaDetails, err := cfg.PokeapiClient.GetLocationAreaDetails(cfg.CurrentArea)
+	if err != nil {
+		return nil, fmt.Errorf("could not get details for %s: %w", cfg.CurrentArea, err)
+	}
+
+	enc, found, err := encounter.Roll(areaDetails, method, cfg.GameVersion, cfg.Rand.Intn)
+	if errors.Is(err, encounter.ErrNoEncounterTable) {
+		methods := encounter.Methods(areaDetails)
+		if len(methods) == 0 {
+			return nil, fmt.Errorf("no wild Pokémon can be encountered in %s", cfg.CurrentArea)
+		}
+		return nil, fmt.Errorf("you can't %s in %s (try: %s)", method, cfg.CurrentArea, strings.Join(methods, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := encounterResult{
+		Area:    cfg.CurrentArea,
+		Method:  method,
+		Version: enc.Version,
+		Found:   found,
+	}
+	if found {
+		// The Pokedex is keyed by species, which differs from the
+		// Pokémon met for alternate forms.
+		pokemon, err := cfg.PokeapiClient.GetPokemonDetails(enc.Pokemon)
+		if err != nil {
+			return nil, err
+		}
+		wildBattle, err := startBattle(cfg, enc)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Battle = wildBattle
+		if wildBattle != nil {
+			res.Battle = currentBattleStatus(wildBattle)
+		}
+
+		cfg.WildEncounter = &enc
+		cfg.Pokedex.MarkSeen(pokemon.Species.Name)
+		res.Pokemon = enc.Pokemon
+		res.Level = enc.Level
+	}
+
+	return res, nil
+}
+
+func (r encounterResult) writeText(w io.Writer, p style.Palette) {
+	if !r.Found {
+		fmt.Fprintln(w, "Nothing appeared.")
+		return
+	}
+	fmt.Fprintf(w, "A wild %s (Lv. %d) appeared!\n", p.Bold(r.Pokemon), r.Level)
+	if r.Battle != nil {
+		fmt.Fprintf(w, "Go! %s!\n", r.Battle.Active)
+		r.Battle.writeText(w, p)
+	}
+}
+
+func (r encounterResult) table() ([]string, [][]string) {
+	if !r.Found {
+		return []string{"area", "method", "pokemon", "level"}, [][]string{}
+	}
+	return []string{"area", "method", "pokemon", "level"}, [][]string{{r.Area, r.Method, r.Pokemon, fmt.Sprint(r.Level)}}
+}
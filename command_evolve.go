@@ -0,0 +1,202 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/GrahamZiervogel/pokedex/internal/evolution"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+type statChange struct {
+	Name   string `json:"name"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+type evolutionResult struct {
+	ID      int          `json:"id"`
+	Pokemon string       `json:"pokemon"`
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	NewDex  bool         `json:"new_pokedex_entry"`
+	Stats   []statChange `json:"stats"`
+	Moves   []moveChange `json:"moves"`
+}
+
+func commandEvolve(cfg *Config, args ...string) (result, error) {
+	if len(args) > 0 {
+		return nil, errors.New("evolve command does not take any arguments")
+	}
+	if cfg.Battle != nil {
+		return nil, errInBattle
+	}
+
+	pending, individual, err := nextEvolution(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if pending.Item != "" && cfg.Inventory.Count(pending.Item) == 0 {
+		return nil, fmt.Errorf("you no longer have a %s; cancel the evolution or buy another", pending.Item)
+	}
+
+	before, err := cfg.PokeapiClient.GetPokemonDetails(individual.Species)
+	if err != nil {
+		return nil, err
+	}
+	after, err := cfg.PokeapiClient.GetPokemonDetails(pending.To)
+	if err != nil {
+		return nil, err
+	}
+	group, err := versionGroup(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.PendingEvolutions = cfg.PendingEvolutions[1:]
+	if pending.Item != "" {
+		if err := cfg.Inventory.Remove(pending.Item, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	res := evolutionResult{
+		ID:      individual.ID,
+		Pokemon: individual.Name(),
+		From:    individual.Species,
+		To:      after.Name,
+		NewDex:  !cfg.Pokedex.HasCaught(after.Species.Name),
+		Stats:   make([]statChange, 0, len(trainer.StatNames)),
+	}
+
+	statsBefore := individual.ActualStats(baseStats(before))
+	individual.Species = after.Name
+	statsAfter := individual.ActualStats(baseStats(after))
+	for _, stat := range trainer.StatNames {
+		res.Stats = append(res.Stats, statChange{Name: stat, Before: statsBefore[stat], After: statsAfter[stat]})
+	}
+
+	cfg.Pokedex.MarkCaught(after.Species.Name)
+	res.Moves = teachMoves(individual, movesLearnedAt(after, group, individual.Level))
+
+	return res, nil
+}
+
+func commandCancel(cfg *Config, args ...string) (result, error) {
+	if len(args) > 0 {
+		return nil, errors.New("cancel command does not take any arguments")
+	}
+
+	pending, _, err := nextEvolution(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cfg.PendingEvolutions = cfg.PendingEvolutions[1:]
+
+	return messageResult{Message: fmt.Sprintf("Huh? %s stopped evolving!", pending.Pokemon)}, nil
+}
+
+// nextEvolution returns the first pending evolution whose individual is
+// still owned, dropping any that were released in the meantime.
+func nextEvolution(cfg *Config) (pendingEvolution, *trainer.Pokemon, error) {
+	for len(cfg.PendingEvolutions) > 0 {
+		pending := cfg.PendingEvolutions[0]
+		if individual, ok := cfg.Collection.Get(pending.ID); ok && individual.Species == pending.From {
+			return pending, individual, nil
+		}
+		cfg.PendingEvolutions = cfg.PendingEvolutions[1:]
+	}
+	return pendingEvolution{}, nil, errors.New("no Pokémon is evolving right now")
+}
+
+func commandUse(cfg *Config, args ...string) (result, error) {
+	if len(args) != 2 {
+		return nil, errors.New("usage: use <item> <pokemon>")
+	}
+	if cfg.Battle != nil {
+		return nil, errInBattle
+	}
+	if cfg.Inventory.Count(args[0]) == 0 {
+		return nil, fmt.Errorf("you don't have any %s", args[0])
+	}
+
+	// The item is only used up once the evolution is confirmed.
+	individual, pending, err := triggerEvolution(cfg, args[1], evolution.Trigger{Kind: evolution.TriggerUseItem, Item: args[0]})
+	if err != nil {
+		return nil, err
+	}
+	if pending == nil {
+		return nil, fmt.Errorf("it won't have any effect on %s", individual.Name())
+	}
+	return pending, nil
+}
+
+func commandTrade(cfg *Config, args ...string) (result, error) {
+	if len(args) != 1 {
+		return nil, errors.New("usage: trade <pokemon>")
+	}
+	if cfg.Battle != nil {
+		return nil, errInBattle
+	}
+
+	individual, pending, err := triggerEvolution(cfg, args[0], evolution.Trigger{Kind: evolution.TriggerTrade})
+	if err != nil {
+		return nil, err
+	}
+	if pending == nil {
+		return messageResult{Message: fmt.Sprintf("You traded %s to a friend, who sent it straight back.", individual.Name())}, nil
+	}
+	return pending, nil
+}
+
+// triggerEvolution checks a trigger other than levelling up for one
+// individual and queues the evolution it causes, if any.
+func triggerEvolution(cfg *Config, ref string, trigger evolution.Trigger) (*trainer.Pokemon, *pendingEvolution, error) {
+	individual, err := cfg.Collection.Find(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pokemon, err := cfg.PokeapiClient.GetPokemonDetails(individual.Species)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pending, err := queueEvolution(cfg, individual, pokemon, trigger)
+	if err != nil {
+		return nil, nil, err
+	}
+	return individual, pending, nil
+}
+
+func (r evolutionResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprintf(w, "Congratulations! Your %s evolved into %s!\n", r.Pokemon, p.Good(r.To))
+	if r.NewDex {
+		fmt.Fprintf(w, "%s's data was added to the Pokedex.\n", r.To)
+	}
+	for _, stat := range r.Stats {
+		fmt.Fprintf(w, "  -%s: %d -> %d (%+d)\n", stat.Name, stat.Before, stat.After, stat.After-stat.Before)
+	}
+	writeMoveChanges(w, r.Pokemon, r.Moves)
+}
+
+func writeMoveChanges(w io.Writer, name string, changes []moveChange) {
+	for _, change := range changes {
+		if change.Forgotten != "" {
+			fmt.Fprintf(w, "%s forgot %s and learned %s!\n", name, change.Forgotten, change.Learned)
+			continue
+		}
+		fmt.Fprintf(w, "%s learned %s!\n", name, change.Learned)
+	}
+}
+
+func (r evolutionResult) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Stats))
+	for _, stat := range r.Stats {
+		rows = append(rows, []string{stat.Name, strconv.Itoa(stat.Before), strconv.Itoa(stat.After)})
+	}
+	return []string{"stat", r.From, r.To}, rows
+}
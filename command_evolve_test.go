@@ -0,0 +1,107 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+// catchWithEvolvingLead catches a caterpie while the party lead is a
+// metapod just short of level 12, where it evolves into butterfree and
+// learns confusion.
+func catchWithEvolvingLead(t *testing.T) (*Config, *strings.Builder) {
+	t.Helper()
+
+	cfg := newAreaTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+
+	cfg.Collection.Add(trainer.Pokemon{Species: "metapod", Level: 11, Experience: 1720, Moves: []string{"harden"}})
+
+	for _, line := range []string{"goto viridian-forest-area", "walk"} {
+		if err := runCommand(cfg, line); err != nil {
+			t.Fatalf("%s failed: %v", line, err)
+		}
+	}
+	cfg.Inventory.Add("master-ball", 1)
+	b.Reset()
+	if err := runCommand(cfg, "catch --ball master-ball"); err != nil {
+		t.Fatalf("catch failed: %v", err)
+	}
+
+	if !strings.Contains(b.String(), "metapod grew to level 12!\nWhat? metapod is evolving into butterfree!") {
+		t.Fatalf("expected the lead to start evolving, got %q", b.String())
+	}
+	return cfg, &b
+}
+
+func TestCommandEvolve(t *testing.T) {
+	cfg, b := catchWithEvolvingLead(t)
+
+	b.Reset()
+	if err := runCommand(cfg, "evolve"); err != nil {
+		t.Fatalf("evolve failed: %v", err)
+	}
+	if !strings.Contains(b.String(), "Congratulations! Your metapod evolved into butterfree!") ||
+		!strings.Contains(b.String(), "metapod learned confusion!") {
+		t.Errorf("unexpected evolution output %q", b.String())
+	}
+
+	lead := cfg.Collection.Pokemon[0]
+	if lead.Species != "butterfree" || !slices.Equal(lead.Moves, []string{"harden", "confusion"}) {
+		t.Errorf("expected a butterfree that learned confusion, got %+v", lead)
+	}
+	if !cfg.Pokedex.HasCaught("butterfree") {
+		t.Error("expected butterfree to be recorded in the Pokedex")
+	}
+
+	if err := runCommand(cfg, "evolve"); err == nil {
+		t.Error("expected evolve to fail with nothing evolving")
+	}
+}
+
+func TestCommandCancel(t *testing.T) {
+	cfg, b := catchWithEvolvingLead(t)
+
+	b.Reset()
+	if err := runCommand(cfg, "cancel"); err != nil {
+		t.Fatalf("cancel failed: %v", err)
+	}
+	if b.String() != "Huh? metapod stopped evolving!\n" {
+		t.Errorf("unexpected cancel output %q", b.String())
+	}
+	if cfg.Collection.Pokemon[0].Species != "metapod" || len(cfg.PendingEvolutions) != 0 {
+		t.Errorf("expected the lead to stay a metapod, got %+v", cfg.Collection.Pokemon[0])
+	}
+}
+
+func TestCommandUseAndTrade_NoEvolution(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+
+	cfg.Collection.Add(trainer.Pokemon{Species: "caterpie", Level: 5})
+
+	err := runCommand(cfg, "use moon-stone caterpie")
+	if err == nil || err.Error() != "you don't have any moon-stone" {
+		t.Errorf("expected using an item not in the bag to fail, got %v", err)
+	}
+
+	cfg.Inventory.Add("moon-stone", 1)
+	err = runCommand(cfg, "use moon-stone caterpie")
+	if err == nil || err.Error() != "it won't have any effect on caterpie" {
+		t.Errorf("expected the moon stone to have no effect, got %v", err)
+	}
+	if cfg.Inventory.Count("moon-stone") != 1 {
+		t.Error("expected an item without effect to stay in the bag")
+	}
+
+	if err := runCommand(cfg, "trade 1"); err != nil {
+		t.Fatalf("trade failed: %v", err)
+	}
+	if b.String() != "You traded caterpie to a friend, who sent it straight back.\n" || len(cfg.PendingEvolutions) != 0 {
+		t.Errorf("expected a trade without evolution, got %q", b.String())
+	}
+}
@@ -3,14 +3,28 @@ package main
 import (
 	"errors"
 	"fmt"
-	"os"
 )
 
-func commandExit(cfg *Config, args ...string) error {
-	if len(args) > 0 {
-		return errors.New("exit command does not take any arguments")
+// errExit is returned by exit once progress is saved. The REPL and the
+// script runner stop when they see it, so a sourced script can end the
+// session without cutting short the scripts that sourced it.
+var errExit = errors.New("exit requested")
+
+func commandExit(cfg *Config, args ...string) (result, error) {
+	positional, flags, err := parseFlags(args, map[string]bool{"no-save": false})
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) > 0 {
+		return nil, errors.New("exit command does not take any arguments")
 	}
-	fmt.Println("Closing the Pokedex... Goodbye!")
-	os.Exit(0)
-	return nil
+
+	if flags["no-save"] == "" {
+		if err := savePlayerState(cfg); err != nil {
+			return nil, fmt.Errorf("%w (use 'exit --no-save' to quit anyway)", err)
+		}
+	}
+
+	cfg.progressf("Closing the Pokedex... Goodbye!\n")
+	return nil, errExit
 }
@@ -3,30 +3,112 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
 )
 
-func commandExplore(cfg *Config, args ...string) error {
+type exploreEntry struct {
+	Name   string `json:"name"`
+	Caught bool   `json:"caught"`
+}
+
+type exploreResult struct {
+	Area    string         `json:"area"`
+	Pokemon []exploreEntry `json:"pokemon"`
+}
+
+func commandExplore(cfg *Config, args ...string) (result, error) {
 	if len(args) != 1 {
-		return errors.New("you must provide exactly one location area name to explore")
+		return nil, errors.New("you must provide exactly one location area name to explore")
 	}
 	locationAreaName := args[0]
 
-	fmt.Printf("Exploring %s...\n", locationAreaName)
-
 	areaDetails, err := cfg.PokeapiClient.GetLocationAreaDetails(locationAreaName)
 	if err != nil {
-		return fmt.Errorf("could not get details for %s: %w", locationAreaName, err)
+		return nil, fmt.Errorf("could not get details for %s: %w", locationAreaName, err)
 	}
 
-	if len(areaDetails.PokemonEncounters) == 0 {
-		fmt.Printf("No Pokémon found in %s.\n", locationAreaName)
-		return nil
-	}
+	cfg.progressf("Exploring %s...\n", localizedAreaName(areaDetails, cfg.Language))
 
-	fmt.Println("Found Pokemon:")
+	res := exploreResult{
+		Area:    locationAreaName,
+		Pokemon: make([]exploreEntry, 0, len(areaDetails.PokemonEncounters)),
+	}
 	for _, encounter := range areaDetails.PokemonEncounters {
-		fmt.Printf(" - %s\n", encounter.Pokemon.Name)
+		if !encounterInVersion(encounter.VersionDetails, cfg.GameVersion) {
+			continue
+		}
+		// The Pokedex is keyed by species, which differs from the
+		// Pokémon's name for alternate forms.
+		pokemon, err := cfg.PokeapiClient.GetPokemonDetails(encounter.Pokemon.Name)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Pokedex.MarkSeen(pokemon.Species.Name)
+		res.Pokemon = append(res.Pokemon, exploreEntry{
+			Name:   encounter.Pokemon.Name,
+			Caught: cfg.Pokedex.HasCaught(pokemon.Species.Name),
+		})
 	}
 
-	return nil
+	return res, nil
+}
+
+// localizedAreaName returns the area's display name in the configured
+// language, falling back to its identifier.
+func localizedAreaName(areaDetails pokeapi.LocationAreaDetailsResponse, language string) string {
+	for _, name := range areaDetails.Names {
+		if name.Language.Name == language && name.Name != "" {
+			return name.Name
+		}
+	}
+	return areaDetails.Name
+}
+
+// encounterInVersion reports whether a Pokémon appears in the configured
+// game version. With no version configured every encounter counts.
+func encounterInVersion(versionDetails []pokeapi.EncounterVersionDetails, gameVersion string) bool {
+	if gameVersion == "" {
+		return true
+	}
+	for _, details := range versionDetails {
+		if details.Version.Name == gameVersion {
+			return true
+		}
+	}
+	return false
+}
+
+func (r exploreResult) writeText(w io.Writer, p style.Palette) {
+	if len(r.Pokemon) == 0 {
+		fmt.Fprintf(w, "No Pokémon found in %s.\n", r.Area)
+		return
+	}
+
+	fmt.Fprintln(w, p.Header("Found Pokemon:"))
+	for _, entry := range r.Pokemon {
+		if !p.Enabled() {
+			fmt.Fprintf(w, " - %s\n", entry.Name)
+			continue
+		}
+		if entry.Caught {
+			fmt.Fprintf(w, " %s %s\n", p.Good("●"), entry.Name)
+		} else {
+			fmt.Fprintf(w, " %s %s\n", p.Dim("○"), p.Dim(entry.Name))
+		}
+	}
+}
+
+func (r exploreResult) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Pokemon))
+	for _, entry := range r.Pokemon {
+		caught := "no"
+		if entry.Caught {
+			caught = "yes"
+		}
+		rows = append(rows, []string{entry.Name, caught})
+	}
+	return []string{"pokemon", "caught"}, rows
 }
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseFlags splits command arguments into positional arguments and
+// --flag options. spec maps each accepted flag name to whether it takes a
+// value; boolean flags are reported with the value "true".
+func parseFlags(args []string, spec map[string]bool) ([]string, map[string]string, error) {
+	positional := []string{}
+	flags := make(map[string]string)
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") || arg == "--" {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		takesValue, known := spec[name]
+		if !known {
+			return nil, nil, fmt.Errorf("unknown option --%s", name)
+		}
+
+		if !takesValue {
+			if hasValue {
+				return nil, nil, fmt.Errorf("option --%s does not take a value", name)
+			}
+			flags[name] = "true"
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, nil, fmt.Errorf("option --%s requires a value", name)
+			}
+			i++
+			value = args[i]
+		}
+		flags[name] = value
+	}
+
+	return positional, flags, nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+)
+
+type gotoResult struct {
+	Area        string `json:"area"`
+	DisplayName string `json:"display_name"`
+	Moved       bool   `json:"moved"`
+}
+
+func commandGoto(cfg *Config, args ...string) (result, error) {
+	if len(args) > 1 {
+		return nil, errors.New("you must provide exactly one location area name to go to")
+	}
+
+	if len(args) == 0 {
+		if cfg.CurrentArea == "" {
+			return messageResult{Message: "You are not in any location area yet. Use 'goto <location_area_name>' to travel."}, nil
+		}
+		areaDetails, err := cfg.PokeapiClient.GetLocationAreaDetails(cfg.CurrentArea)
+		if err != nil {
+			return nil, fmt.Errorf("could not get details for %s: %w", cfg.CurrentArea, err)
+		}
+		return gotoResult{Area: cfg.CurrentArea, DisplayName: localizedAreaName(areaDetails, cfg.Language)}, nil
+	}
+
+	if cfg.Battle != nil {
+		return nil, errInBattle
+	}
+
+	areaDetails, err := cfg.PokeapiClient.GetLocationAreaDetails(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("could not travel to %s: %w", args[0], err)
+	}
+
+	if cfg.CurrentArea != areaDetails.Name {
+		cfg.WildEncounter = nil
+	}
+	cfg.CurrentArea = areaDetails.Name
+
+	return gotoResult{
+		Area:        areaDetails.Name,
+		DisplayName: localizedAreaName(areaDetails, cfg.Language),
+		Moved:       true,
+	}, nil
+}
+
+func (r gotoResult) writeText(w io.Writer, p style.Palette) {
+	if r.Moved {
+		fmt.Fprintf(w, "You traveled to %s.\n", p.Bold(r.DisplayName))
+		return
+	}
+	fmt.Fprintf(w, "You are in %s.\n", p.Bold(r.DisplayName))
+}
+
+func (r gotoResult) table() ([]string, [][]string) {
+	return []string{"area", "name"}, [][]string{{r.Area, r.DisplayName}}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/GrahamZiervogel/pokedex/internal/config"
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func newAreaTestConfig(t *testing.T) *Config {
+	t.Helper()
+
+	server := pokeapitest.New().Start()
+	t.Cleanup(server.Close)
+
+	settings := config.Defaults()
+	settings.BaseURL = server.URL
+	settings.SavePath = ""
+	// With this seed the first walk in Viridian Forest meets a level 3
+	// caterpie in red.
+	seed := int64(33)
+	settings.Seed = &seed
+	cfg := newConfig(settings)
+	cfg.Out = &strings.Builder{}
+	return cfg
+}
+
+// newFormTestConfig serves an area where the only wild Pokémon is an
+// alternate form, whose name differs from its species.
+func newFormTestConfig(t *testing.T) *Config {
+	t.Helper()
+
+	fixtures, err := pokeapitest.NewFromFS(fstest.MapFS{
+		"location-area/trophy-garden-area.json": {Data: []byte(`{
+			"id": 1, "name": "trophy-garden-area",
+			"encounter_method_rates": [{"encounter_method": {"name": "walk"}, "version_details": [{"rate": 100, "version": {"name": "platinum"}}]}],
+			"pokemon_encounters": [{"pokemon": {"name": "wormadam-sandy"}, "version_details": [{"version": {"name": "platinum"}, "encounter_details": [
+				{"min_level": 20, "max_level": 20, "chance": 100, "method": {"name": "walk"}}
+			]}]}]
+		}`)},
+		"pokemon/wormadam-sandy.json": {Data: []byte(`{"id": 10004, "name": "wormadam-sandy", "species": {"name": "wormadam"}}`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := fixtures.Start()
+	t.Cleanup(server.Close)
+
+	settings := config.Defaults()
+	settings.BaseURL = server.URL
+	settings.SavePath = ""
+	cfg := newConfig(settings)
+	cfg.Out = &strings.Builder{}
+	return cfg
+}
+
+func TestCommandGoto(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+
+	if err := runCommand(cfg, "goto viridian-forest-area"); err != nil {
+		t.Fatalf("goto failed: %v", err)
+	}
+	if cfg.CurrentArea != "viridian-forest-area" {
+		t.Errorf("expected current area to be set, got '%s'", cfg.CurrentArea)
+	}
+	if prompt(cfg) != "Pokedex (viridian-forest-area) > " {
+		t.Errorf("expected prompt to show the current area, got '%s'", prompt(cfg))
+	}
+
+	if err := runCommand(cfg, "goto nowhere"); err == nil {
+		t.Error("expected an error travelling to an unknown area")
+	}
+	if cfg.CurrentArea != "viridian-forest-area" {
+		t.Errorf("expected failed travel to keep the current area, got '%s'", cfg.CurrentArea)
+	}
+}
+
+func TestCommandCatch_RequiresWildEncounter(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+
+	err := runCommand(cfg, "walk")
+	if err == nil || !strings.Contains(err.Error(), "goto") {
+		t.Errorf("expected walking outside any area to fail, got %v", err)
+	}
+
+	err = runCommand(cfg, "catch caterpie")
+	if err == nil || !strings.Contains(err.Error(), "no wild Pokémon") {
+		t.Errorf("expected catching without an encounter to fail, got %v", err)
+	}
+	cfg.Inventory.Remove("poke-ball", cfg.Inventory.Count("poke-ball"))
+	err = runCommand(cfg, "catch")
+	if err == nil || !strings.Contains(err.Error(), "no wild Pokémon") {
+		t.Errorf("expected a missing encounter to be reported before missing balls, got %v", err)
+	}
+	cfg.Inventory.Add("poke-ball", 5)
+
+	if err := runCommand(cfg, "goto viridian-forest-area"); err != nil {
+		t.Fatalf("goto failed: %v", err)
+	}
+
+	err = runCommand(cfg, "surf")
+	if err == nil || !strings.Contains(err.Error(), "try: walk") {
+		t.Errorf("expected surfing in a forest to fail, got %v", err)
+	}
+
+	if err := runCommand(cfg, "walk"); err != nil {
+		t.Fatalf("walk failed: %v", err)
+	}
+	if cfg.WildEncounter == nil || cfg.WildEncounter.Pokemon != "caterpie" || cfg.WildEncounter.Level != 3 {
+		t.Fatalf("expected a level 3 caterpie encounter, got %+v", cfg.WildEncounter)
+	}
+
+	err = runCommand(cfg, "catch bulbasaur")
+	if err == nil || !strings.Contains(err.Error(), "is caterpie, not bulbasaur") {
+		t.Errorf("expected catching a different Pokémon to fail, got %v", err)
+	}
+}
+
+func TestCommandWalk_MarksSpeciesSeen(t *testing.T) {
+	cfg := newFormTestConfig(t)
+
+	for _, line := range []string{"goto trophy-garden-area", "walk"} {
+		if err := runCommand(cfg, line); err != nil {
+			t.Fatalf("%s failed: %v", line, err)
+		}
+	}
+	if cfg.WildEncounter == nil || cfg.WildEncounter.Pokemon != "wormadam-sandy" {
+		t.Fatalf("expected to meet wormadam-sandy, got %+v", cfg.WildEncounter)
+	}
+	if !cfg.Pokedex.HasSeen("wormadam") || cfg.Pokedex.HasSeen("wormadam-sandy") {
+		t.Errorf("expected the species to be marked seen rather than the form, got %v", cfg.Pokedex)
+	}
+}
@@ -3,19 +3,27 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
 	"sort"
+
+	"github.com/GrahamZiervogel/pokedex/internal/style"
 )
 
-func commandHelp(cfg *Config, args ...string) error {
+type helpEntry struct {
+	Command     string `json:"command"`
+	Usage       string `json:"usage"`
+	Description string `json:"description"`
+}
+
+type helpResult struct {
+	Commands []helpEntry `json:"commands"`
+}
+
+func commandHelp(cfg *Config, args ...string) (result, error) {
 	if len(args) > 0 {
-		return errors.New("help command does not take any arguments")
+		return nil, errors.New("help command does not take any arguments")
 	}
 
-	fmt.Println()
-	fmt.Println("Welcome to the Pokedex!")
-	fmt.Println("Usage:")
-	fmt.Println()
-
 	commands := getCommands()
 	commandNames := make([]string, 0, len(commands))
 
@@ -25,11 +33,36 @@ func commandHelp(cfg *Config, args ...string) error {
 
 	sort.Strings(commandNames)
 
+	res := helpResult{Commands: make([]helpEntry, 0, len(commandNames))}
 	for _, name := range commandNames {
 		cmd := commands[name]
-		fmt.Printf("%s: %s\n", cmd.name, cmd.description)
+		res.Commands = append(res.Commands, helpEntry{
+			Command:     name,
+			Usage:       cmd.name,
+			Description: cmd.description,
+		})
+	}
+
+	return res, nil
+}
+
+func (r helpResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, p.Header("Welcome to the Pokedex!"))
+	fmt.Fprintln(w, p.Header("Usage:"))
+	fmt.Fprintln(w)
+
+	for _, entry := range r.Commands {
+		fmt.Fprintf(w, "%s: %s\n", p.Bold(entry.Usage), entry.Description)
 	}
 
-	fmt.Println()
-	return nil
+	fmt.Fprintln(w)
+}
+
+func (r helpResult) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Commands))
+	for _, entry := range r.Commands {
+		rows = append(rows, []string{entry.Usage, entry.Description})
+	}
+	return []string{"usage", "description"}, rows
 }
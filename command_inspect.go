@@ -3,33 +3,185 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/output"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
 )
 
-func commandInspect(cfg *Config, args ...string) error {
-	if len(args) != 1 {
-		return errors.New("you must provide exactly one Pokémon name to inspect")
+type statValue struct {
+	Name   string `json:"name"`
+	Base   int    `json:"base"`
+	IV     int    `json:"iv"`
+	EV     int    `json:"ev"`
+	Actual int    `json:"actual"`
+}
+
+type inspectResult struct {
+	ID       int       `json:"id"`
+	Name     string    `json:"name"`
+	Nickname string    `json:"nickname,omitempty"`
+	Level    int       `json:"level"`
+	CaughtAt time.Time `json:"caught_at"`
+
+	Experience     int    `json:"experience"`
+	NextLevelAfter int    `json:"next_level_after,omitempty"`
+	Nature         string `json:"nature"`
+	Ability        string `json:"ability,omitempty"`
+
+	Location string      `json:"location"`
+	Height   int         `json:"height"`
+	Weight   int         `json:"weight"`
+	Stats    []statValue `json:"stats"`
+	Types    []string    `json:"types"`
+	Moves    []string    `json:"moves"`
+
+	sprite string
+}
+
+func commandInspect(cfg *Config, args ...string) (result, error) {
+	positional, flags, err := parseFlags(args, map[string]bool{"sprite": false})
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 1 {
+		return nil, errors.New("you must provide exactly one Pokémon to inspect")
+	}
+
+	individual, err := cfg.Collection.Find(positional[0])
+	if err != nil {
+		return nil, err
 	}
-	pokemonName := args[0]
 
-	pokemon, caught := cfg.Pokedex[pokemonName]
-	if !caught {
-		fmt.Println("you have not caught that pokemon")
-		return nil
+	pokemon, err := cfg.PokeapiClient.GetPokemonDetails(individual.Species)
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Printf("Name: %s\n", pokemon.Name)
-	fmt.Printf("Height: %d\n", pokemon.Height)
-	fmt.Printf("Weight: %d\n", pokemon.Weight)
+	curve, err := growthCurve(cfg, pokemon)
+	if err != nil {
+		return nil, err
+	}
 
-	fmt.Println("Stats:")
+	res := inspectResult{
+		ID:         individual.ID,
+		Name:       pokemon.Name,
+		Nickname:   individual.Nickname,
+		Level:      individual.Level,
+		Experience: individual.Experience,
+		Nature:     individual.Nature.Name,
+		Ability:    individual.Ability,
+		CaughtAt:   individual.CaughtAt,
+		Location:   individual.Location,
+		Height:     pokemon.Height,
+		Weight:     pokemon.Weight,
+		Stats:      make([]statValue, 0, len(pokemon.Stats)),
+		Types:      make([]string, 0, len(pokemon.Types)),
+		Moves:      append([]string{}, individual.Moves...),
+	}
+	if individual.Level < trainer.MaxLevel {
+		res.NextLevelAfter = curve.Experience(individual.Level+1) - individual.Experience
+	}
+	actual := individual.ActualStats(baseStats(pokemon))
 	for _, statEntry := range pokemon.Stats {
-		fmt.Printf("  -%s: %d\n", statEntry.Stat.Name, statEntry.BaseStat)
+		name := statEntry.Stat.Name
+		res.Stats = append(res.Stats, statValue{
+			Name:   name,
+			Base:   statEntry.BaseStat,
+			IV:     individual.IVs[name],
+			EV:     individual.EVs[name],
+			Actual: actual[name],
+		})
 	}
-
-	fmt.Println("Types:")
 	for _, typeEntry := range pokemon.Types {
-		fmt.Printf("  - %s\n", typeEntry.Type.Name)
+		res.Types = append(res.Types, typeEntry.Type.Name)
+	}
+
+	if flags["sprite"] != "" && cfg.outputFormat() == output.Text {
+		res.sprite, err = renderSprite(cfg, pokemon.Sprites.FrontDefault)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return nil
+	return res, nil
+}
+
+func (r inspectResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprint(w, r.sprite)
+	fmt.Fprintf(w, "%s #%d\n", p.Header("ID:"), r.ID)
+	fmt.Fprintf(w, "%s %s\n", p.Header("Name:"), r.Name)
+	if r.Nickname != "" {
+		fmt.Fprintf(w, "%s %s\n", p.Header("Nickname:"), r.Nickname)
+	}
+	fmt.Fprintf(w, "%s %d\n", p.Header("Level:"), r.Level)
+	if r.NextLevelAfter > 0 {
+		fmt.Fprintf(w, "%s %d (%d to next level)\n", p.Header("Exp. Points:"), r.Experience, r.NextLevelAfter)
+	} else {
+		fmt.Fprintf(w, "%s %d\n", p.Header("Exp. Points:"), r.Experience)
+	}
+	if r.Nature != "" {
+		fmt.Fprintf(w, "%s %s\n", p.Header("Nature:"), r.Nature)
+	}
+	if r.Ability != "" {
+		fmt.Fprintf(w, "%s %s\n", p.Header("Ability:"), r.Ability)
+	}
+	fmt.Fprintf(w, "%s %s in %s\n", p.Header("Caught:"), r.CaughtAt.Local().Format(time.DateOnly), r.Location)
+	fmt.Fprintf(w, "%s %d\n", p.Header("Height:"), r.Height)
+	fmt.Fprintf(w, "%s %d\n", p.Header("Weight:"), r.Weight)
+
+	fmt.Fprintln(w, p.Header("Stats:"))
+	writeStats(w, p, r.Stats)
+
+	fmt.Fprintln(w, p.Header("Types:"))
+	for _, typeName := range r.Types {
+		fmt.Fprintf(w, "  - %s\n", p.Type(typeName))
+	}
+
+	if len(r.Moves) > 0 {
+		fmt.Fprintln(w, p.Header("Moves:"))
+		for _, move := range r.Moves {
+			fmt.Fprintf(w, "  - %s\n", move)
+		}
+	}
+}
+
+func (r inspectResult) table() ([]string, [][]string) {
+	rows := [][]string{
+		{"id", strconv.Itoa(r.ID)},
+		{"name", r.Name},
+		{"nickname", r.Nickname},
+		{"nature", r.Nature},
+		{"ability", r.Ability},
+		{"level", strconv.Itoa(r.Level)},
+		{"experience", strconv.Itoa(r.Experience)},
+		{"next_level_after", strconv.Itoa(r.NextLevelAfter)},
+		{"caught_at", r.CaughtAt.Format(time.RFC3339)},
+		{"location", r.Location},
+		{"height", strconv.Itoa(r.Height)},
+		{"weight", strconv.Itoa(r.Weight)},
+		{"types", strings.Join(r.Types, ", ")},
+		{"moves", strings.Join(r.Moves, ", ")},
+	}
+	for _, stat := range r.Stats {
+		rows = append(rows, []string{stat.Name, fmt.Sprintf("%d (base %d, IV %d, EV %d)", stat.Actual, stat.Base, stat.IV, stat.EV)})
+	}
+	return []string{"field", "value"}, rows
+}
+
+// writeStats lists actual stats alongside the values they come from, with
+// a bar scaled to the base stat when color is enabled.
+func writeStats(w io.Writer, p style.Palette, stats []statValue) {
+	for _, stat := range stats {
+		if !p.Enabled() {
+			fmt.Fprintf(w, "  -%s: %d (base %d, IV %d, EV %d)\n", stat.Name, stat.Actual, stat.Base, stat.IV, stat.EV)
+			continue
+		}
+		fmt.Fprintf(w, "  -%-16s %3d %s %s\n", stat.Name+":", stat.Actual, p.StatBar(stat.Base),
+			p.Dim(fmt.Sprintf("base %d, IV %d, EV %d", stat.Base, stat.IV, stat.EV)))
+	}
 }
@@ -3,63 +3,95 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
 )
 
-func commandMap(cfg *Config, args ...string) error {
+type mapResult struct {
+	Areas    []pokeapi.NamedAPIResource `json:"areas"`
+	Next     *string                    `json:"next"`
+	Previous *string                    `json:"previous"`
+
+	backwards bool
+}
+
+func commandMap(cfg *Config, args ...string) (result, error) {
 	if len(args) > 0 {
-		return errors.New("map command does not take any arguments")
+		return nil, errors.New("map command does not take any arguments")
 	}
 
-	fmt.Println("Fetching next location areas...")
+	cfg.progressf("Fetching next location areas...\n")
 	locationResponse, err := cfg.PokeapiClient.ListLocationAreas(cfg.NextLocationAreasURL)
 	if err != nil {
-		return fmt.Errorf("could not get location areas: %w", err)
+		return nil, fmt.Errorf("could not get location areas: %w", err)
 	}
 
 	cfg.NextLocationAreasURL = locationResponse.Next
 	cfg.PreviousLocationAreasURL = locationResponse.Previous
 
-	if len(locationResponse.Results) == 0 {
-		fmt.Println("No more location areas found.")
-		return nil
-	}
-
-	fmt.Println("Location Areas:")
-	for _, area := range locationResponse.Results {
-		fmt.Printf("- %s\n", area.Name)
-	}
-
-	return nil
+	return newMapResult(locationResponse, false), nil
 }
 
-func commandMapb(cfg *Config, args ...string) error {
+func commandMapb(cfg *Config, args ...string) (result, error) {
 	if len(args) > 0 {
-		return errors.New("mapb command does not take any arguments")
+		return nil, errors.New("mapb command does not take any arguments")
 	}
 
 	if cfg.PreviousLocationAreasURL == nil || *cfg.PreviousLocationAreasURL == "" {
-		fmt.Println("You are at the first page of locations, cannot go back.")
-		return nil
+		return messageResult{Message: "You are at the first page of locations, cannot go back."}, nil
 	}
 
-	fmt.Println("Fetching previous location areas...")
+	cfg.progressf("Fetching previous location areas...\n")
 	locationResponse, err := cfg.PokeapiClient.ListLocationAreas(cfg.PreviousLocationAreasURL)
 	if err != nil {
-		return fmt.Errorf("could not get previous location areas: %w", err)
+		return nil, fmt.Errorf("could not get previous location areas: %w", err)
 	}
 
 	cfg.NextLocationAreasURL = locationResponse.Next
 	cfg.PreviousLocationAreasURL = locationResponse.Previous
 
-	if len(locationResponse.Results) == 0 {
-		fmt.Println("No location areas found on the previous page.")
-		return nil
+	return newMapResult(locationResponse, true), nil
+}
+
+func newMapResult(locationResponse pokeapi.LocationAreaResponse, backwards bool) mapResult {
+	areas := locationResponse.Results
+	if areas == nil {
+		areas = []pokeapi.NamedAPIResource{}
+	}
+	return mapResult{
+		Areas:     areas,
+		Next:      locationResponse.Next,
+		Previous:  locationResponse.Previous,
+		backwards: backwards,
+	}
+}
+
+func (r mapResult) writeText(w io.Writer, p style.Palette) {
+	if len(r.Areas) == 0 {
+		if r.backwards {
+			fmt.Fprintln(w, "No location areas found on the previous page.")
+		} else {
+			fmt.Fprintln(w, "No more location areas found.")
+		}
+		return
 	}
 
-	fmt.Println("Location Areas (Previous):")
-	for _, area := range locationResponse.Results {
-		fmt.Printf("- %s\n", area.Name)
+	if r.backwards {
+		fmt.Fprintln(w, p.Header("Location Areas (Previous):"))
+	} else {
+		fmt.Fprintln(w, p.Header("Location Areas:"))
+	}
+	for _, area := range r.Areas {
+		fmt.Fprintf(w, "- %s\n", area.Name)
 	}
+}
 
-	return nil
+func (r mapResult) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Areas))
+	for _, area := range r.Areas {
+		rows = append(rows, []string{area.Name, area.URL})
+	}
+	return []string{"name", "url"}, rows
 }
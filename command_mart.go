@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+)
+
+// martStock is what the Poké Mart sells. Prices and descriptions come from
+// PokeAPI's item resource.
+var martStock = []string{
+	"poke-ball",
+	"great-ball",
+	"ultra-ball",
+	"potion",
+	"super-potion",
+	"hyper-potion",
+	"max-potion",
+	"fire-stone",
+	"water-stone",
+	"thunder-stone",
+	"leaf-stone",
+	"moon-stone",
+}
+
+// maxItemQuantity caps how many of an item can be bought or sold at once,
+// like the games do, and keeps price totals far from overflowing.
+const maxItemQuantity = 999
+
+type martEntry struct {
+	Item     string `json:"item"`
+	Price    int    `json:"price"`
+	Category string `json:"category"`
+	Effect   string `json:"effect"`
+}
+
+type martResult struct {
+	Money int         `json:"money"`
+	Items []martEntry `json:"items"`
+}
+
+type bagEntry struct {
+	Item     string `json:"item"`
+	Quantity int    `json:"quantity"`
+	Category string `json:"category"`
+}
+
+type bagResult struct {
+	Money int        `json:"money"`
+	Items []bagEntry `json:"items"`
+}
+
+func commandMart(cfg *Config, args ...string) (result, error) {
+	if len(args) > 0 {
+		return nil, errors.New("mart command does not take any arguments")
+	}
+
+	res := martResult{Money: cfg.Inventory.Money, Items: make([]martEntry, 0, len(martStock))}
+	for _, name := range martStock {
+		item, err := cfg.PokeapiClient.GetItem(name)
+		if err != nil {
+			return nil, err
+		}
+		res.Items = append(res.Items, martEntry{
+			Item:     item.Name,
+			Price:    item.Cost,
+			Category: item.Category.Name,
+			Effect:   itemEffect(item, cfg.Language),
+		})
+	}
+	return res, nil
+}
+
+func commandBuy(cfg *Config, args ...string) (result, error) {
+	name, quantity, err := parseItemQuantity(args, "usage: buy <item> [quantity]")
+	if err != nil {
+		return nil, err
+	}
+
+	stocked := false
+	for _, stock := range martStock {
+		stocked = stocked || stock == name
+	}
+	if !stocked {
+		return nil, fmt.Errorf("the Poké Mart doesn't sell %s", name)
+	}
+
+	item, err := cfg.PokeapiClient.GetItem(name)
+	if err != nil {
+		return nil, err
+	}
+	total := item.Cost * quantity
+	if err := cfg.Inventory.Spend(total); err != nil {
+		return nil, err
+	}
+	cfg.Inventory.Add(item.Name, quantity)
+
+	return messageResult{Message: fmt.Sprintf("You bought %d %s for ₽%d. You have ₽%d left.", quantity, item.Name, total, cfg.Inventory.Money)}, nil
+}
+
+// commandSell sells items back to the Poké Mart for half their price.
+func commandSell(cfg *Config, args ...string) (result, error) {
+	name, quantity, err := parseItemQuantity(args, "usage: sell <item> [quantity]")
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := cfg.PokeapiClient.GetItem(name)
+	if err != nil {
+		return nil, err
+	}
+	if item.Cost == 0 {
+		return nil, fmt.Errorf("%s can't be sold", item.Name)
+	}
+	if err := cfg.Inventory.Remove(item.Name, quantity); err != nil {
+		return nil, err
+	}
+	total := item.Cost / 2 * quantity
+	cfg.Inventory.Money += total
+
+	return messageResult{Message: fmt.Sprintf("You sold %d %s for ₽%d. You have ₽%d now.", quantity, item.Name, total, cfg.Inventory.Money)}, nil
+}
+
+func parseItemQuantity(args []string, usage string) (string, int, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return "", 0, errors.New(usage)
+	}
+
+	quantity := 1
+	if len(args) == 2 {
+		var err error
+		quantity, err = strconv.Atoi(args[1])
+		if err != nil || quantity < 1 {
+			return "", 0, fmt.Errorf("invalid quantity '%s'", args[1])
+		}
+		if quantity > maxItemQuantity {
+			return "", 0, fmt.Errorf("quantity can be at most %d, got %d", maxItemQuantity, quantity)
+		}
+	}
+	return args[0], quantity, nil
+}
+
+func commandBag(cfg *Config, args ...string) (result, error) {
+	if len(args) > 0 {
+		return nil, errors.New("bag command does not take any arguments")
+	}
+
+	res := bagResult{Money: cfg.Inventory.Money, Items: []bagEntry{}}
+	for _, name := range cfg.Inventory.Names() {
+		item, err := cfg.PokeapiClient.GetItem(name)
+		if err != nil {
+			return nil, err
+		}
+		res.Items = append(res.Items, bagEntry{Item: name, Quantity: cfg.Inventory.Count(name), Category: item.Category.Name})
+	}
+	return res, nil
+}
+
+// itemEffect is the item's short effect in the configured language,
+// falling back to English.
+func itemEffect(item pokeapi.Item, language string) string {
+	effect := ""
+	for _, entry := range item.EffectEntries {
+		if entry.Language.Name == language {
+			return entry.ShortEffect
+		}
+		if entry.Language.Name == "en" {
+			effect = entry.ShortEffect
+		}
+	}
+	return effect
+}
+
+func (r martResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprintf(w, "%s (you have ₽%d)\n", p.Header("Poké Mart:"), r.Money)
+	for _, entry := range r.Items {
+		fmt.Fprintf(w, " - %-14s ₽%-6d %s\n", entry.Item, entry.Price, p.Dim(entry.Effect))
+	}
+}
+
+func (r martResult) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Items))
+	for _, entry := range r.Items {
+		rows = append(rows, []string{entry.Item, strconv.Itoa(entry.Price), entry.Category, entry.Effect})
+	}
+	return []string{"item", "price", "category", "effect"}, rows
+}
+
+func (r bagResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprintf(w, "%s ₽%d\n", p.Header("Money:"), r.Money)
+	fmt.Fprintln(w, p.Header("Bag:"))
+	if len(r.Items) == 0 {
+		fmt.Fprintln(w, " (empty)")
+		return
+	}
+	for _, entry := range r.Items {
+		fmt.Fprintf(w, " - %s x%d %s\n", entry.Item, entry.Quantity, p.Dim("("+strings.ReplaceAll(entry.Category, "-", " ")+")"))
+	}
+}
+
+func (r bagResult) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Items))
+	for _, entry := range r.Items {
+		rows = append(rows, []string{entry.Item, strconv.Itoa(entry.Quantity), entry.Category})
+	}
+	return []string{"item", "quantity", "category"}, rows
+}
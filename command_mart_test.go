@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuySellAndBag(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+
+	if err := runCommand(cfg, "buy great-ball 3"); err != nil {
+		t.Fatalf("buy failed: %v", err)
+	}
+	if cfg.Inventory.Money != 1200 || cfg.Inventory.Count("great-ball") != 3 {
+		t.Errorf("expected 3 great balls for ₽1800, got ₽%d and %d", cfg.Inventory.Money, cfg.Inventory.Count("great-ball"))
+	}
+
+	if err := runCommand(cfg, "buy great-ball 3"); err == nil || !strings.Contains(err.Error(), "only have ₽1200") {
+		t.Errorf("expected buying without enough money to fail, got %v", err)
+	}
+	for _, input := range []string{"buy poke-ball 92233720368547759", "buy poke-ball 1000", "sell poke-ball 92233720368547759"} {
+		if err := runCommand(cfg, input); err == nil || !strings.Contains(err.Error(), "quantity") {
+			t.Errorf("expected %q to be rejected, got %v", input, err)
+		}
+	}
+	if cfg.Inventory.Money != 1200 || cfg.Inventory.Count("poke-ball") != 5 {
+		t.Errorf("expected rejected trades to change nothing, got ₽%d and %d poke balls", cfg.Inventory.Money, cfg.Inventory.Count("poke-ball"))
+	}
+	if err := runCommand(cfg, "buy rare-candy"); err == nil {
+		t.Error("expected buying an item the mart doesn't stock to fail")
+	}
+
+	if err := runCommand(cfg, "sell great-ball"); err != nil {
+		t.Fatalf("sell failed: %v", err)
+	}
+	if cfg.Inventory.Money != 1500 || cfg.Inventory.Count("great-ball") != 2 {
+		t.Errorf("expected to sell a great ball for ₽300, got ₽%d", cfg.Inventory.Money)
+	}
+
+	b.Reset()
+	if err := runCommand(cfg, "bag"); err != nil {
+		t.Fatalf("bag failed: %v", err)
+	}
+	expected := "Money: ₽1500\nBag:\n - great-ball x2 (standard balls)\n - poke-ball x5 (standard balls)\n - potion x1 (healing)\n"
+	if b.String() != expected {
+		t.Errorf("expected bag %q, got %q", expected, b.String())
+	}
+}
+
+func TestCatch_UsesChosenBall(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+
+	for _, line := range []string{"goto viridian-forest-area", "walk"} {
+		if err := runCommand(cfg, line); err != nil {
+			t.Fatalf("%s failed: %v", line, err)
+		}
+	}
+
+	if err := runCommand(cfg, "catch --ball ultra-ball"); err == nil || !strings.Contains(err.Error(), "don't have any ultra-ball") {
+		t.Errorf("expected throwing a ball not in the bag to fail, got %v", err)
+	}
+	if err := runCommand(cfg, "catch --ball potion"); err == nil {
+		t.Error("expected throwing a potion to fail")
+	}
+
+	cfg.Inventory.Add("master-ball", 1)
+	if err := runCommand(cfg, "catch caterpie --ball master-ball"); err != nil {
+		t.Fatalf("catch failed: %v", err)
+	}
+	if len(cfg.Collection.Pokemon) != 1 || cfg.Inventory.Count("master-ball") != 0 || cfg.Inventory.Count("poke-ball") != 5 {
+		t.Errorf("expected the master ball to be used up on a sure catch, got %v", cfg.Inventory.Items)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/GrahamZiervogel/pokedex/internal/output"
+)
+
+func commandOutput(cfg *Config, args ...string) (result, error) {
+	if len(args) > 1 {
+		return nil, errors.New("output command takes at most one format name")
+	}
+
+	if len(args) == 0 {
+		return messageResult{Message: fmt.Sprintf("Output format: %s", cfg.OutputFormat)}, nil
+	}
+
+	format, err := output.ParseFormat(args[0])
+	if err != nil {
+		return nil, err
+	}
+	cfg.OutputFormat = format
+
+	return messageResult{Message: fmt.Sprintf("Output format set to %s", format)}, nil
+}
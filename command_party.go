@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+type storageResult struct {
+	Title   string            `json:"title"`
+	Box     int               `json:"box,omitempty"`
+	Boxes   int               `json:"boxes"`
+	Pokemon []trainer.Pokemon `json:"pokemon"`
+}
+
+func commandParty(cfg *Config, args ...string) (result, error) {
+	if len(args) > 0 {
+		return nil, errors.New("party command does not take any arguments")
+	}
+
+	return storageResult{
+		Title:   fmt.Sprintf("Your party (%d/%d):", len(cfg.Collection.Party), trainer.PartySize),
+		Boxes:   len(cfg.Collection.Boxes),
+		Pokemon: cfg.Collection.PartyMembers(),
+	}, nil
+}
+
+func commandBox(cfg *Config, args ...string) (result, error) {
+	if len(args) > 1 {
+		return nil, errors.New("usage: box [number]")
+	}
+
+	n := 1
+	if len(args) == 1 {
+		var err error
+		n, err = strconv.Atoi(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid box number '%s'", args[0])
+		}
+	}
+
+	res := storageResult{
+		Title:   fmt.Sprintf("Box %d (%d boxes in use):", n, len(cfg.Collection.Boxes)),
+		Box:     n,
+		Boxes:   len(cfg.Collection.Boxes),
+		Pokemon: []trainer.Pokemon{},
+	}
+	if len(cfg.Collection.Boxes) == 0 && n == 1 {
+		return res, nil
+	}
+
+	members, err := cfg.Collection.BoxMembers(n)
+	if err != nil {
+		return nil, err
+	}
+	res.Pokemon = members
+	return res, nil
+}
+
+func commandDeposit(cfg *Config, args ...string) (result, error) {
+	if len(args) != 1 {
+		return nil, errors.New("usage: deposit <id|nickname|species>")
+	}
+	if cfg.Battle != nil {
+		return nil, errInBattle
+	}
+
+	individual, err := cfg.Collection.Find(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	place, err := cfg.Collection.Deposit(individual.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return messageResult{Message: fmt.Sprintf("%s (#%d) was deposited in %s.", individual.Name(), individual.ID, place)}, nil
+}
+
+func commandWithdraw(cfg *Config, args ...string) (result, error) {
+	if len(args) != 1 {
+		return nil, errors.New("usage: withdraw <id|nickname|species>")
+	}
+	if cfg.Battle != nil {
+		return nil, errInBattle
+	}
+
+	individual, err := cfg.Collection.Find(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Collection.Withdraw(individual.ID); err != nil {
+		return nil, err
+	}
+
+	return messageResult{Message: fmt.Sprintf("%s (#%d) joined your party.", individual.Name(), individual.ID)}, nil
+}
+
+func commandSwap(cfg *Config, args ...string) (result, error) {
+	if len(args) != 2 {
+		return nil, errors.New("usage: swap <pokemon> <pokemon>")
+	}
+	if cfg.Battle != nil {
+		return nil, errInBattle
+	}
+
+	first, err := cfg.Collection.Find(args[0])
+	if err != nil {
+		return nil, err
+	}
+	second, err := cfg.Collection.Find(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if first.ID == second.ID {
+		return nil, errors.New("you must name two different Pokémon to swap")
+	}
+
+	if err := cfg.Collection.Swap(first.ID, second.ID); err != nil {
+		return nil, err
+	}
+
+	firstPlace, _ := cfg.Collection.Locate(first.ID)
+	secondPlace, _ := cfg.Collection.Locate(second.ID)
+	return messageResult{Message: fmt.Sprintf("%s is now in %s and %s is now in %s.",
+		first.Name(), firstPlace, second.Name(), secondPlace)}, nil
+}
+
+func (r storageResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprintln(w, p.Header(r.Title))
+
+	if len(r.Pokemon) == 0 {
+		fmt.Fprintln(w, " (empty)")
+		return
+	}
+
+	for i, pokemon := range r.Pokemon {
+		name := pokemon.Species
+		if pokemon.Nickname != "" {
+			name = fmt.Sprintf("%s (%s)", p.Bold(pokemon.Nickname), pokemon.Species)
+		}
+		fmt.Fprintf(w, " %2d. #%-3d %s Lv. %d\n", i+1, pokemon.ID, name, pokemon.Level)
+	}
+}
+
+func (r storageResult) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Pokemon))
+	for i, pokemon := range r.Pokemon {
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			strconv.Itoa(pokemon.ID),
+			pokemon.Species,
+			pokemon.Nickname,
+			strconv.Itoa(pokemon.Level),
+		})
+	}
+	return []string{"slot", "id", "species", "nickname", "level"}, rows
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/config"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+func TestPartyCommands(t *testing.T) {
+	cfg := newConfig(config.Defaults())
+	var b strings.Builder
+	cfg.Out = &b
+
+	for i := 0; i < trainer.PartySize+1; i++ {
+		cfg.Collection.Add(trainer.Pokemon{Species: "magikarp", Level: 5})
+	}
+
+	b.Reset()
+	if err := runCommand(cfg, "box"); err != nil {
+		t.Fatalf("box failed: %v", err)
+	}
+	if !strings.Contains(b.String(), "#7") {
+		t.Errorf("expected the seventh catch in Box 1, got %q", b.String())
+	}
+
+	if err := runCommand(cfg, "withdraw 7"); err == nil {
+		t.Error("expected withdrawing into a full party to fail")
+	}
+
+	if err := runCommand(cfg, "swap 1 7"); err != nil {
+		t.Fatalf("swap failed: %v", err)
+	}
+	if cfg.Collection.Party[0] != 7 {
+		t.Errorf("expected #7 to lead the party, got %v", cfg.Collection.Party)
+	}
+
+	if err := runCommand(cfg, "deposit 2"); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	if err := runCommand(cfg, "withdraw 1"); err != nil {
+		t.Fatalf("withdraw failed: %v", err)
+	}
+
+	b.Reset()
+	if err := runCommand(cfg, "party"); err != nil {
+		t.Fatalf("party failed: %v", err)
+	}
+	if !strings.HasPrefix(b.String(), "Your party (6/6):\n  1. #7 ") {
+		t.Errorf("unexpected party output %q", b.String())
+	}
+
+	if err := runCommand(cfg, "box 3"); err == nil {
+		t.Error("expected an unused box number to fail")
+	}
+}
@@ -1,32 +1,191 @@
 package main
 
 import (
-	"errors"
 	"fmt"
+	"io"
 	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GrahamZiervogel/pokedex/internal/query"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
 )
 
-func commandPokedex(cfg *Config, args ...string) error {
+type pokedexEntry struct {
+	Species string            `json:"species"`
+	Status  trainer.DexStatus `json:"status"`
+}
+
+type pokedexResult struct {
+	Seen    int            `json:"seen"`
+	Caught  int            `json:"caught"`
+	Entries []pokedexEntry `json:"entries"`
+}
+
+type pokedexMatch struct {
+	ID       int                `json:"id"`
+	Species  string             `json:"species"`
+	Nickname string             `json:"nickname,omitempty"`
+	Level    int                `json:"level"`
+	Types    []string           `json:"types"`
+	Values   map[string]float64 `json:"values"`
+}
+
+type pokedexQueryResult struct {
+	Query   string         `json:"query"`
+	Fields  []string       `json:"fields"`
+	Total   int            `json:"total"`
+	Matches []pokedexMatch `json:"matches"`
+}
+
+// commandPokedex lists every species seen or caught. Given a query such as
+// "type:water attack>80 sort:-speed limit:5" it instead searches the caught
+// Pokémon by the base stats and details of their species.
+func commandPokedex(cfg *Config, args ...string) (result, error) {
 	if len(args) > 0 {
-		return errors.New("pokedex command does not take any arguments")
+		return queryPokedex(cfg, strings.Join(args, " "))
+	}
+
+	res := pokedexResult{Entries: make([]pokedexEntry, 0, len(cfg.Pokedex))}
+	for species, status := range cfg.Pokedex {
+		res.Entries = append(res.Entries, pokedexEntry{Species: species, Status: status})
+		res.Seen++
+		if status == trainer.Caught {
+			res.Caught++
+		}
 	}
+	sort.Slice(res.Entries, func(i, j int) bool {
+		return res.Entries[i].Species < res.Entries[j].Species
+	})
 
-	fmt.Println("Your Pokedex:")
+	return res, nil
+}
 
-	if len(cfg.Pokedex) == 0 {
-		fmt.Println(" (is empty)")
-		return nil
+func queryPokedex(cfg *Config, expression string) (result, error) {
+	q, err := query.Parse(expression)
+	if err != nil {
+		return nil, err
 	}
 
-	pokemonNames := make([]string, 0, len(cfg.Pokedex))
-	for name := range cfg.Pokedex {
-		pokemonNames = append(pokemonNames, name)
+	records := make([]query.Record, 0, len(cfg.Collection.Pokemon))
+	individuals := make(map[int]trainer.Pokemon, len(cfg.Collection.Pokemon))
+	for _, individual := range cfg.Collection.Pokemon {
+		pokemon, err := cfg.PokeapiClient.GetPokemonDetails(individual.Species)
+		if err != nil {
+			return nil, err
+		}
+
+		record := query.Record{
+			ID:       individual.ID,
+			Dex:      pokemon.ID,
+			Level:    individual.Level,
+			Names:    []string{individual.Name()},
+			HeightM:  float64(pokemon.Height) / 10,
+			WeightKg: float64(pokemon.Weight) / 10,
+			Stats:    baseStats(pokemon),
+		}
+		if individual.Nickname != "" {
+			record.Names = append(record.Names, individual.Species)
+		}
+		for _, typeEntry := range pokemon.Types {
+			record.Types = append(record.Types, typeEntry.Type.Name)
+		}
+		for _, abilityEntry := range pokemon.Abilities {
+			record.Abilities = append(record.Abilities, abilityEntry.Ability.Name)
+		}
+		records = append(records, record)
+		individuals[individual.ID] = individual
 	}
-	sort.Strings(pokemonNames)
 
-	for _, name := range pokemonNames {
-		fmt.Printf(" - %s\n", name)
+	res := pokedexQueryResult{
+		Query:   expression,
+		Fields:  append([]string{}, q.Fields()...),
+		Total:   len(records),
+		Matches: []pokedexMatch{},
+	}
+	for _, record := range q.Apply(records) {
+		individual := individuals[record.ID]
+		match := pokedexMatch{
+			ID:       individual.ID,
+			Species:  individual.Species,
+			Nickname: individual.Nickname,
+			Level:    individual.Level,
+			Types:    append([]string{}, record.Types...),
+			Values:   make(map[string]float64, len(res.Fields)),
+		}
+		for _, field := range res.Fields {
+			match.Values[field] = record.Number(field)
+		}
+		res.Matches = append(res.Matches, match)
 	}
+	return res, nil
+}
+
+func (r pokedexQueryResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprintln(w, p.Header(fmt.Sprintf("Matching Pokémon (%d of %d):", len(r.Matches), r.Total)))
 
-	return nil
+	if len(r.Matches) == 0 {
+		fmt.Fprintln(w, " (none)")
+		return
+	}
+
+	for _, match := range r.Matches {
+		name := match.Species
+		if match.Nickname != "" {
+			name = fmt.Sprintf("%s (%s)", p.Bold(match.Nickname), match.Species)
+		}
+		types := make([]string, len(match.Types))
+		for i, typeName := range match.Types {
+			types[i] = p.Type(typeName)
+		}
+		line := fmt.Sprintf(" #%-3d %s Lv. %d [%s]", match.ID, name, match.Level, strings.Join(types, "/"))
+		if len(r.Fields) > 0 {
+			values := make([]string, len(r.Fields))
+			for i, field := range r.Fields {
+				values[i] = fmt.Sprintf("%s %g", field, match.Values[field])
+			}
+			line += " " + p.Dim(strings.Join(values, ", "))
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func (r pokedexQueryResult) table() ([]string, [][]string) {
+	headers := append([]string{"id", "species", "nickname", "level", "types"}, r.Fields...)
+	rows := make([][]string, 0, len(r.Matches))
+	for _, match := range r.Matches {
+		row := []string{strconv.Itoa(match.ID), match.Species, match.Nickname, strconv.Itoa(match.Level), strings.Join(match.Types, ", ")}
+		for _, field := range r.Fields {
+			row = append(row, strconv.FormatFloat(match.Values[field], 'f', -1, 64))
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
+
+func (r pokedexResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprintln(w, p.Header("Your Pokedex:"))
+
+	if len(r.Entries) == 0 {
+		fmt.Fprintln(w, " (is empty)")
+		return
+	}
+
+	for _, entry := range r.Entries {
+		if entry.Status == trainer.Caught {
+			fmt.Fprintf(w, " - %s\n", entry.Species)
+		} else {
+			fmt.Fprintf(w, " - %s %s\n", p.Dim(entry.Species), p.Dim("(seen)"))
+		}
+	}
+	fmt.Fprintf(w, "Seen: %d, Caught: %d\n", r.Seen, r.Caught)
+}
+
+func (r pokedexResult) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Entries))
+	for _, entry := range r.Entries {
+		rows = append(rows, []string{entry.Species, string(entry.Status)})
+	}
+	return []string{"species", "status"}, rows
 }
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+func TestCommandPokedex_Query(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+	cfg.Collection.Add(trainer.Pokemon{Species: "caterpie", Level: 3})
+	cfg.Collection.Add(trainer.Pokemon{Species: "metapod", Nickname: "Shell", Level: 7})
+	cfg.Collection.Add(trainer.Pokemon{Species: "caterpie", Level: 9})
+
+	if err := runCommand(cfg, "pokedex type:bug defense>30 sort:-level limit:2"); err != nil {
+		t.Fatalf("pokedex query failed: %v", err)
+	}
+	expected := "Matching Pokémon (2 of 3):\n" +
+		" #3   caterpie Lv. 9 [bug] defense 35, level 9\n" +
+		" #2   Shell (metapod) Lv. 7 [bug] defense 55, level 7\n"
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+
+	b.Reset()
+	if err := runCommand(cfg, "pokedex name:metapod ability:shed-skin weight>9.5"); err != nil {
+		t.Fatalf("pokedex query failed: %v", err)
+	}
+	if !strings.Contains(b.String(), "(1 of 3)") || !strings.Contains(b.String(), "Shell") {
+		t.Errorf("expected to find Shell, got:\n%s", b.String())
+	}
+
+	err := runCommand(cfg, "pokedex type:bug atack>80")
+	if err == nil || !strings.Contains(err.Error(), "column 10") {
+		t.Errorf("expected a parse error pointing at the unknown field, got %v", err)
+	}
+}
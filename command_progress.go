@@ -0,0 +1,245 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+type missingEntry struct {
+	Number  int    `json:"number"`
+	Species string `json:"species"`
+	Seen    bool   `json:"seen"`
+}
+
+type dexProgress struct {
+	Name    string         `json:"name"`
+	Region  string         `json:"region,omitempty"`
+	Total   int            `json:"total"`
+	Seen    int            `json:"seen"`
+	Caught  int            `json:"caught"`
+	Missing []missingEntry `json:"missing,omitempty"`
+}
+
+type progressResult struct {
+	Pokedexes   []dexProgress `json:"pokedexes"`
+	Generations []dexProgress `json:"generations"`
+}
+
+type dexEntry struct {
+	number  int
+	species string
+}
+
+// commandProgress measures completion of the national and regional
+// Pokédexes and of each generation. Naming one of them lists the species
+// not caught yet.
+func commandProgress(cfg *Config, args ...string) (result, error) {
+	if len(args) > 1 {
+		return nil, errors.New("usage: progress [pokedex or generation]")
+	}
+
+	national, err := cfg.PokeapiClient.GetPokedex("national")
+	if err != nil {
+		return nil, err
+	}
+	statuses := cfg.Pokedex
+
+	res := progressResult{Pokedexes: []dexProgress{}, Generations: []dexProgress{}}
+	if len(args) == 1 {
+		name := args[0]
+		if strings.HasPrefix(name, "generation-") {
+			generation, err := cfg.PokeapiClient.GetGeneration(name)
+			if err != nil {
+				return nil, err
+			}
+			progress := measureProgress(generation.Name, generation.MainRegion.Name, generationEntries(generation), statuses, true)
+			res.Generations = append(res.Generations, progress)
+			return res, nil
+		}
+
+		pokedex, err := cfg.PokeapiClient.GetPokedex(name)
+		if err != nil {
+			return nil, err
+		}
+		res.Pokedexes = append(res.Pokedexes, measureProgress(pokedex.Name, regionName(pokedex), pokedexEntries(pokedex), statuses, true))
+		return res, nil
+	}
+
+	res.Pokedexes = append(res.Pokedexes, measureProgress(national.Name, "", pokedexEntries(national), statuses, false))
+	pokedexes, err := cfg.PokeapiClient.ListPokedexes()
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range pokedexes {
+		if resource.Name == national.Name {
+			continue
+		}
+		pokedex, err := cfg.PokeapiClient.GetPokedex(resource.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !pokedex.IsMainSeries {
+			continue
+		}
+		res.Pokedexes = append(res.Pokedexes, measureProgress(pokedex.Name, regionName(pokedex), pokedexEntries(pokedex), statuses, false))
+	}
+
+	generations, err := cfg.PokeapiClient.ListGenerations()
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range generations {
+		generation, err := cfg.PokeapiClient.GetGeneration(resource.Name)
+		if err != nil {
+			return nil, err
+		}
+		res.Generations = append(res.Generations, measureProgress(generation.Name, generation.MainRegion.Name, generationEntries(generation), statuses, false))
+	}
+
+	return res, nil
+}
+
+func regionName(pokedex pokeapi.Pokedex) string {
+	if pokedex.Region == nil {
+		return ""
+	}
+	return pokedex.Region.Name
+}
+
+func pokedexEntries(pokedex pokeapi.Pokedex) []dexEntry {
+	entries := make([]dexEntry, 0, len(pokedex.PokemonEntries))
+	for _, entry := range pokedex.PokemonEntries {
+		entries = append(entries, dexEntry{number: entry.EntryNumber, species: entry.PokemonSpecies.Name})
+	}
+	return entries
+}
+
+// generationEntries numbers a generation's species by their national
+// Pokédex number, taken from the species URL, since the API lists them in
+// no particular order.
+func generationEntries(generation pokeapi.Generation) []dexEntry {
+	entries := make([]dexEntry, 0, len(generation.PokemonSpecies))
+	for _, species := range generation.PokemonSpecies {
+		number, _ := strconv.Atoi(pokeapi.ResourceID(species.URL))
+		entries = append(entries, dexEntry{number: number, species: species.Name})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].number < entries[j].number
+	})
+	return entries
+}
+
+func measureProgress(name, region string, entries []dexEntry, statuses trainer.Pokedex, listMissing bool) dexProgress {
+	progress := dexProgress{Name: name, Region: region, Total: len(entries)}
+	if listMissing {
+		progress.Missing = []missingEntry{}
+	}
+	for _, entry := range entries {
+		if statuses.HasSeen(entry.species) {
+			progress.Seen++
+		}
+		if statuses.HasCaught(entry.species) {
+			progress.Caught++
+			continue
+		}
+		if listMissing {
+			progress.Missing = append(progress.Missing, missingEntry{
+				Number:  entry.number,
+				Species: entry.species,
+				Seen:    statuses.HasSeen(entry.species),
+			})
+		}
+	}
+	return progress
+}
+
+func percent(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) * 100 / float64(total)
+}
+
+func (d dexProgress) label() string {
+	if d.Region == "" || d.Region == d.Name {
+		return d.Name
+	}
+	return fmt.Sprintf("%s (%s)", d.Name, d.Region)
+}
+
+func (d dexProgress) summary() string {
+	return fmt.Sprintf("seen %d/%d (%.1f%%), caught %d/%d (%.1f%%)",
+		d.Seen, d.Total, percent(d.Seen, d.Total), d.Caught, d.Total, percent(d.Caught, d.Total))
+}
+
+func (r progressResult) writeText(w io.Writer, p style.Palette) {
+	sections := []struct {
+		title   string
+		entries []dexProgress
+	}{
+		{"Pokédexes:", r.Pokedexes},
+		{"Generations:", r.Generations},
+	}
+
+	width := 0
+	for _, section := range sections {
+		for _, progress := range section.entries {
+			width = max(width, len(progress.label()))
+		}
+	}
+
+	for _, section := range sections {
+		if len(section.entries) == 0 {
+			continue
+		}
+		fmt.Fprintln(w, p.Header(section.title))
+		for _, progress := range section.entries {
+			summary := progress.summary()
+			if progress.Total > 0 && progress.Caught == progress.Total {
+				summary = p.Good(summary)
+			}
+			fmt.Fprintf(w, " %-*s  %s\n", width, progress.label(), summary)
+
+			if progress.Missing == nil {
+				continue
+			}
+			if len(progress.Missing) == 0 {
+				fmt.Fprintln(w, " Nothing is missing. Congratulations!")
+				continue
+			}
+			fmt.Fprintln(w, p.Header("Missing:"))
+			for _, entry := range progress.Missing {
+				if entry.Seen {
+					fmt.Fprintf(w, " #%03d %s %s\n", entry.Number, entry.Species, p.Dim("(seen)"))
+				} else {
+					fmt.Fprintf(w, " #%03d %s\n", entry.Number, entry.Species)
+				}
+			}
+		}
+	}
+}
+
+func (r progressResult) table() ([]string, [][]string) {
+	var rows [][]string
+	for _, group := range [][]dexProgress{r.Pokedexes, r.Generations} {
+		for _, progress := range group {
+			rows = append(rows, []string{
+				progress.Name,
+				progress.Region,
+				strconv.Itoa(progress.Seen),
+				strconv.Itoa(progress.Caught),
+				strconv.Itoa(progress.Total),
+				strconv.FormatFloat(percent(progress.Caught, progress.Total), 'f', 1, 64),
+			})
+		}
+	}
+	return []string{"name", "region", "seen", "caught", "total", "caught_percent"}, rows
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandProgress(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+
+	if err := runCommand(cfg, "explore viridian-forest-area"); err != nil {
+		t.Fatalf("explore failed: %v", err)
+	}
+	if !cfg.Pokedex.HasSeen("caterpie") || cfg.Pokedex.HasCaught("caterpie") {
+		t.Errorf("expected explore to mark caterpie as seen, got %v", cfg.Pokedex)
+	}
+	cfg.Pokedex.MarkCaught("metapod")
+
+	b.Reset()
+	if err := runCommand(cfg, "progress"); err != nil {
+		t.Fatalf("progress failed: %v", err)
+	}
+	expected := "Pokédexes:\n" +
+		" national              seen 4/13 (30.8%), caught 1/13 (7.7%)\n" +
+		" kanto                 seen 4/13 (30.8%), caught 1/13 (7.7%)\n" +
+		"Generations:\n" +
+		" generation-i (kanto)  seen 4/13 (30.8%), caught 1/13 (7.7%)\n"
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+
+	b.Reset()
+	if err := runCommand(cfg, "progress generation-i"); err != nil {
+		t.Fatalf("progress failed: %v", err)
+	}
+	expected = "Generations:\n" +
+		" generation-i (kanto)  seen 4/13 (30.8%), caught 1/13 (7.7%)\n" +
+		"Missing:\n" +
+		" #001 bulbasaur\n" +
+		" #004 charmander\n" +
+		" #007 squirtle\n" +
+		" #010 caterpie (seen)\n" +
+		" #012 butterfree\n" +
+		" #013 weedle (seen)\n" +
+		" #016 pidgey\n" +
+		" #019 rattata\n" +
+		" #025 pikachu (seen)\n" +
+		" #026 raichu\n" +
+		" #041 zubat\n" +
+		" #074 geodude\n"
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+
+	if err := runCommand(cfg, "progress orre"); err == nil {
+		t.Error("expected an unknown pokedex to fail")
+	}
+}
+
+func TestCommandExplore_MarksSpeciesSeen(t *testing.T) {
+	cfg := newFormTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+	cfg.Pokedex.MarkCaught("wormadam")
+
+	if err := runCommand(cfg, "explore trophy-garden-area --output json"); err != nil {
+		t.Fatalf("explore failed: %v", err)
+	}
+	if !strings.Contains(b.String(), `"name": "wormadam-sandy"`) || !strings.Contains(b.String(), `"caught": true`) {
+		t.Errorf("expected the form to show as caught through its species, got %s", b.String())
+	}
+	if len(cfg.Pokedex) != 1 {
+		t.Errorf("expected no Pokedex entry for the form itself, got %v", cfg.Pokedex)
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+func commandSave(cfg *Config, args ...string) (result, error) {
+	if len(args) > 0 {
+		return nil, errors.New("save command does not take any arguments")
+	}
+	if cfg.SavePath == "" {
+		return nil, errors.New("no save path is configured")
+	}
+
+	if err := savePlayerState(cfg); err != nil {
+		return nil, err
+	}
+
+	return messageResult{Message: fmt.Sprintf("Game saved to %s", cfg.SavePath)}, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+)
+
+type seedResult struct {
+	Seed     int64 `json:"seed"`
+	Reseeded bool  `json:"reseeded"`
+}
+
+func commandSeed(cfg *Config, args ...string) (result, error) {
+	if len(args) > 1 {
+		return nil, errors.New("seed command takes at most one number")
+	}
+
+	if len(args) == 0 {
+		return seedResult{Seed: cfg.Seed}, nil
+	}
+
+	seed, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("seed must be an integer, got '%s'", args[0])
+	}
+	cfg.Seed = seed
+	cfg.Rand = rand.New(rand.NewSource(seed))
+
+	return seedResult{Seed: seed, Reseeded: true}, nil
+}
+
+func (r seedResult) writeText(w io.Writer, _ style.Palette) {
+	if r.Reseeded {
+		fmt.Fprintf(w, "Random seed set to %d.\n", r.Seed)
+		return
+	}
+	fmt.Fprintf(w, "Random seed: %d (start with --seed %d to replay this session)\n", r.Seed, r.Seed)
+}
+
+func (r seedResult) table() ([]string, [][]string) {
+	return []string{"seed"}, [][]string{{strconv.FormatInt(r.Seed, 10)}}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"errors"
+)
+
+func commandSource(cfg *Config, args ...string) (result, error) {
+	positional, flags, err := parseFlags(args, map[string]bool{
+		"stop-on-error": false,
+		"echo":          false,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 1 {
+		return nil, errors.New("you must provide exactly one script file to source")
+	}
+
+	return nil, runScriptFile(cfg, positional[0], scriptOptions{
+		stopOnError: flags["stop-on-error"] != "",
+		echo:        flags["echo"] != "",
+	})
+}
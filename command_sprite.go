@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/GrahamZiervogel/pokedex/internal/output"
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/termimage"
+)
+
+const spriteMaxWidth = 64
+
+type spriteResult struct {
+	Pokemon string `json:"pokemon"`
+	URL     string `json:"url"`
+	Shiny   bool   `json:"shiny"`
+	Back    bool   `json:"back"`
+
+	rendered string
+}
+
+func commandSprite(cfg *Config, args ...string) (result, error) {
+	positional, flags, err := parseFlags(args, map[string]bool{
+		"shiny": false,
+		"back":  false,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 1 {
+		return nil, errors.New("you must provide exactly one Pokémon name to show")
+	}
+
+	pokemon, err := cfg.PokeapiClient.GetPokemonDetails(positional[0])
+	if err != nil {
+		return nil, err
+	}
+
+	res := spriteResult{
+		Pokemon: pokemon.Name,
+		Shiny:   flags["shiny"] != "",
+		Back:    flags["back"] != "",
+	}
+	res.URL = spriteURL(pokemon, res.Shiny, res.Back)
+	if res.URL == "" {
+		return nil, fmt.Errorf("no %s sprite available for %s", spriteDescription(res.Shiny, res.Back), pokemon.Name)
+	}
+
+	if cfg.outputFormat() == output.Text {
+		res.rendered, err = renderSprite(cfg, res.URL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func spriteURL(pokemon pokeapi.Pokemon, shiny, back bool) string {
+	switch {
+	case back && shiny:
+		return pokemon.Sprites.BackShiny
+	case back:
+		return pokemon.Sprites.BackDefault
+	case shiny:
+		return pokemon.Sprites.FrontShiny
+	default:
+		return pokemon.Sprites.FrontDefault
+	}
+}
+
+func spriteDescription(shiny, back bool) string {
+	side := "front"
+	if back {
+		side = "back"
+	}
+	if shiny {
+		return side + " shiny"
+	}
+	return side
+}
+
+// renderSprite downloads a sprite through the client's cache and draws it
+// for the terminal, using the same color mode as the rest of the output.
+func renderSprite(cfg *Config, url string) (string, error) {
+	img, err := cfg.PokeapiClient.GetSprite(url)
+	if err != nil {
+		return "", fmt.Errorf("could not download sprite: %w", err)
+	}
+
+	rendered, err := termimage.Render(img, spriteMaxWidth, cfg.Palette.Mode)
+	if err != nil {
+		return "", fmt.Errorf("%w (try without --no-color or NO_COLOR)", err)
+	}
+	return rendered, nil
+}
+
+func (r spriteResult) writeText(w io.Writer, _ style.Palette) {
+	fmt.Fprint(w, r.rendered)
+}
+
+func (r spriteResult) table() ([]string, [][]string) {
+	return []string{"pokemon", "sprite", "url"}, [][]string{{r.Pokemon, spriteDescription(r.Shiny, r.Back), r.URL}}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/config"
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+)
+
+func TestCommandSprite(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	settings := config.Defaults()
+	settings.BaseURL = server.URL
+	cfg := newConfig(settings)
+	cfg.Palette = style.Palette{Mode: style.TrueColor}
+	var b strings.Builder
+	cfg.Out = &b
+
+	if err := runCommand(cfg, "sprite pikachu --back --shiny"); err != nil {
+		t.Fatalf("sprite command failed: %v", err)
+	}
+	if !strings.Contains(b.String(), "▀") {
+		t.Errorf("expected half-block output, got %q", b.String())
+	}
+
+	cfg.Palette = style.Palette{}
+	err := runCommand(cfg, "sprite pikachu --back --shiny")
+	if err == nil || !strings.Contains(err.Error(), "color terminal") {
+		t.Errorf("expected a color terminal error without color, got %v", err)
+	}
+
+	err = runCommand(cfg, "sprite caterpie --back")
+	if err == nil || !strings.Contains(err.Error(), "no back sprite available for caterpie") {
+		t.Errorf("expected missing sprite error, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+type statsResult struct {
+	Pokemon string      `json:"pokemon"`
+	Level   int         `json:"level"`
+	Nature  string      `json:"nature"`
+	Stats   []statValue `json:"stats"`
+}
+
+// commandStats calculates actual stats for a caught individual or any
+// species. Options override the individual's own level, nature and
+// values; for a species they default to level 50, a neutral nature,
+// perfect IVs and no EVs.
+func commandStats(cfg *Config, args ...string) (result, error) {
+	positional, flags, err := parseFlags(args, map[string]bool{
+		"level":  true,
+		"nature": true,
+		"iv":     true,
+		"ev":     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 1 {
+		return nil, errors.New("usage: stats <pokemon> [--level N] [--nature X] [--iv N] [--ev N]")
+	}
+
+	subject, err := statSubject(cfg, positional[0])
+	if err != nil {
+		return nil, err
+	}
+	if value, ok := flags["level"]; ok {
+		if subject.Level, err = parseLevel(value); err != nil {
+			return nil, err
+		}
+	}
+	if value, ok := flags["nature"]; ok {
+		subject.Nature, err = lookupNature(cfg, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if value, ok := flags["iv"]; ok {
+		subject.IVs, err = uniformStats(value, trainer.ValidateIV)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if value, ok := flags["ev"]; ok {
+		subject.EVs, err = uniformStats(value, validateUniformEV)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pokemon, err := cfg.PokeapiClient.GetPokemonDetails(subject.Species)
+	if err != nil {
+		return nil, err
+	}
+
+	res := statsResult{
+		Pokemon: pokemon.Name,
+		Level:   subject.Level,
+		Nature:  subject.Nature.Name,
+		Stats:   make([]statValue, 0, len(trainer.StatNames)),
+	}
+	base := baseStats(pokemon)
+	actual := subject.ActualStats(base)
+	for _, stat := range trainer.StatNames {
+		res.Stats = append(res.Stats, statValue{
+			Name:   stat,
+			Base:   base[stat],
+			IV:     subject.IVs[stat],
+			EV:     subject.EVs[stat],
+			Actual: actual[stat],
+		})
+	}
+
+	return res, nil
+}
+
+// uniformStats parses one value to use for all six stats.
+func uniformStats(value string, validate func(int) error) (trainer.Stats, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number '%s'", value)
+	}
+	if err := validate(n); err != nil {
+		return nil, err
+	}
+
+	stats := make(trainer.Stats, len(trainer.StatNames))
+	for _, stat := range trainer.StatNames {
+		stats[stat] = n
+	}
+	return stats, nil
+}
+
+// validateUniformEV checks an EV given to all six stats against the total
+// cap as well as the per-stat one.
+func validateUniformEV(ev int) error {
+	if err := trainer.ValidateEV(ev); err != nil {
+		return err
+	}
+	if total := ev * len(trainer.StatNames); total > trainer.MaxTotalEVs {
+		return fmt.Errorf("EVs add up to %d, more than %d (--ev can be at most %d)", total, trainer.MaxTotalEVs, trainer.MaxTotalEVs/len(trainer.StatNames))
+	}
+	return nil
+}
+
+func (r statsResult) writeText(w io.Writer, p style.Palette) {
+	nature := r.Nature
+	if nature == "" {
+		nature = "neutral"
+	}
+	fmt.Fprintf(w, "%s Lv. %d (%s nature)\n", p.Header(r.Pokemon), r.Level, nature)
+	writeStats(w, p, r.Stats)
+}
+
+func (r statsResult) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Stats))
+	for _, stat := range r.Stats {
+		rows = append(rows, []string{
+			stat.Name,
+			strconv.Itoa(stat.Base),
+			strconv.Itoa(stat.IV),
+			strconv.Itoa(stat.EV),
+			strconv.Itoa(stat.Actual),
+		})
+	}
+	return []string{"stat", "base", "iv", "ev", "actual"}, rows
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+func TestCommandStats(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+
+	if err := runCommand(cfg, "stats caterpie --level 100 --nature adamant --ev 85"); err != nil {
+		t.Fatalf("stats failed: %v", err)
+	}
+	expected := "caterpie Lv. 100 (adamant nature)\n" +
+		"  -hp: 252 (base 45, IV 31, EV 85)\n" +
+		"  -attack: 128 (base 30, IV 31, EV 85)\n" +
+		"  -defense: 127 (base 35, IV 31, EV 85)\n" +
+		"  -special-attack: 87 (base 20, IV 31, EV 85)\n" +
+		"  -special-defense: 97 (base 20, IV 31, EV 85)\n" +
+		"  -speed: 147 (base 45, IV 31, EV 85)\n"
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+
+	err := runCommand(cfg, "stats caterpie --ev 86")
+	if err == nil || err.Error() != "EVs add up to 516, more than 510 (--ev can be at most 85)" {
+		t.Errorf("expected EVs over the total cap to fail, got %v", err)
+	}
+
+	for _, line := range []string{"stats caterpie --level 0", "stats caterpie --iv 32", "stats caterpie --ev 252", "stats caterpie --nature grumpy", "stats"} {
+		if err := runCommand(cfg, line); err == nil {
+			t.Errorf("expected %q to fail", line)
+		}
+	}
+}
+
+func TestCommandStats_CollectionReferences(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	cfg.Collection.Add(trainer.Pokemon{Species: "caterpie", Level: 5})
+	cfg.Collection.Add(trainer.Pokemon{Species: "caterpie", Level: 7})
+
+	err := runCommand(cfg, "stats 3")
+	if err == nil || err.Error() != "you have no Pokémon with ID 3" {
+		t.Errorf("expected an unknown ID to fail rather than show species #3, got %v", err)
+	}
+	err = runCommand(cfg, "stats caterpie")
+	if err == nil || !strings.Contains(err.Error(), "you have 2 caterpie (#1, #2)") {
+		t.Errorf("expected an ambiguous name to fail, got %v", err)
+	}
+	if err := runCommand(cfg, "stats metapod"); err != nil {
+		t.Errorf("expected an uncaught species to fall back to its base stats, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/GrahamZiervogel/pokedex/internal/battle"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+type teamMember struct {
+	Name      string   `json:"name"`
+	Types     []string `json:"types"`
+	MoveTypes []string `json:"move_types"`
+}
+
+type typeMatchup struct {
+	Type    string   `json:"type"`
+	Members []string `json:"members"`
+}
+
+type teamResult struct {
+	Members          []teamMember  `json:"members"`
+	SharedWeaknesses []typeMatchup `json:"shared_weaknesses"`
+	Immunities       []typeMatchup `json:"immunities"`
+	Uncovered        []string      `json:"uncovered"`
+	Suggestion       string        `json:"suggestion,omitempty"`
+}
+
+// commandTeam analyzes the type coverage of a team. Without names it
+// looks at every caught Pokémon, or only the party with --party, since
+// that is the team the player battles with.
+func commandTeam(cfg *Config, args ...string) (result, error) {
+	if len(args) == 0 || args[0] != "analyze" {
+		return nil, errors.New("usage: team analyze [pokemon...] [--party]")
+	}
+	positional, flags, err := parseFlags(args[1:], map[string]bool{"party": false})
+	if err != nil {
+		return nil, err
+	}
+	_, partyOnly := flags["party"]
+	if partyOnly && len(positional) > 0 {
+		return nil, errors.New("--party cannot be combined with named Pokémon")
+	}
+
+	var subjects []trainer.Pokemon
+	for _, ref := range positional {
+		if individual, err := cfg.Collection.Find(ref); err == nil {
+			subjects = append(subjects, *individual)
+			continue
+		}
+		subjects = append(subjects, trainer.Pokemon{Species: ref})
+	}
+	switch {
+	case partyOnly:
+		subjects = cfg.Collection.PartyMembers()
+		if len(subjects) == 0 {
+			return nil, errors.New("your party is empty, name some Pokémon to analyze")
+		}
+	case len(positional) == 0:
+		subjects = cfg.Collection.Pokemon
+		if len(subjects) == 0 {
+			return nil, errors.New("you have not caught any Pokémon, name some Pokémon to analyze")
+		}
+	}
+
+	chart := battle.Chart{}
+	for _, typeName := range battle.Types {
+		t, err := cfg.PokeapiClient.GetType(typeName)
+		if err != nil {
+			return nil, err
+		}
+		chart.Add(t)
+	}
+
+	team := make([]battle.Member, 0, len(subjects))
+	res := teamResult{Members: make([]teamMember, 0, len(subjects))}
+	for _, subject := range subjects {
+		member, err := teamMemberOf(cfg, subject)
+		if err != nil {
+			return nil, err
+		}
+		team = append(team, member)
+		res.Members = append(res.Members, teamMember{Name: member.Name, Types: member.Types, MoveTypes: member.MoveTypes})
+	}
+
+	coverage := battle.Analyze(chart, team)
+	res.SharedWeaknesses = typeMatchups(coverage.SharedWeaknesses)
+	res.Immunities = typeMatchups(coverage.Immunities)
+	res.Uncovered = append([]string{}, coverage.Uncovered...)
+	res.Suggestion = coverage.Suggestion
+	return res, nil
+}
+
+// teamMemberOf collects the types a Pokémon can attack with: those of its
+// damaging moves, or its own types when it knows no moves at all, as is the
+// case for species that were named rather than caught.
+func teamMemberOf(cfg *Config, subject trainer.Pokemon) (battle.Member, error) {
+	pokemon, err := cfg.PokeapiClient.GetPokemonDetails(subject.Species)
+	if err != nil {
+		return battle.Member{}, err
+	}
+
+	member := battle.Member{Name: pokemon.Name, Types: []string{}, MoveTypes: []string{}}
+	if subject.ID != 0 {
+		member.Name = subject.Name()
+	}
+	for _, typeEntry := range pokemon.Types {
+		member.Types = append(member.Types, typeEntry.Type.Name)
+	}
+
+	if len(subject.Moves) == 0 {
+		member.MoveTypes = append(member.MoveTypes, member.Types...)
+		return member, nil
+	}
+	for _, moveName := range subject.Moves {
+		move, err := battleMove(cfg, moveName)
+		if err != nil {
+			return battle.Member{}, err
+		}
+		if move.Category == battle.CategoryStatus || move.Power == 0 || slices.Contains(member.MoveTypes, move.Type) {
+			continue
+		}
+		member.MoveTypes = append(member.MoveTypes, move.Type)
+	}
+	return member, nil
+}
+
+func typeMatchups(matchups []battle.Matchup) []typeMatchup {
+	res := make([]typeMatchup, 0, len(matchups))
+	for _, matchup := range matchups {
+		res = append(res, typeMatchup{Type: matchup.Type, Members: matchup.Members})
+	}
+	return res
+}
+
+func (r teamResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprintln(w, p.Header("Team:"))
+	for _, member := range r.Members {
+		fmt.Fprintf(w, " - %s (%s), attacks with %s\n", member.Name, styledTypes(p, member.Types), orNone(styledTypes(p, member.MoveTypes)))
+	}
+
+	fmt.Fprintln(w, p.Header("Shared weaknesses:"))
+	writeMatchups(w, p, r.SharedWeaknesses, p.Bad)
+	fmt.Fprintln(w, p.Header("Immunities:"))
+	writeMatchups(w, p, r.Immunities, p.Good)
+
+	fmt.Fprintln(w, p.Header("Not hit super effectively:"))
+	fmt.Fprintf(w, "  %s\n", orNone(styledTypes(p, r.Uncovered)))
+
+	if r.Suggestion != "" {
+		fmt.Fprintf(w, "%s a %s type Pokémon would fill the most gaps\n", p.Header("Suggestion:"), p.Type(r.Suggestion))
+	}
+}
+
+func writeMatchups(w io.Writer, p style.Palette, matchups []typeMatchup, highlight func(string) string) {
+	if len(matchups) == 0 {
+		fmt.Fprintln(w, "  (none)")
+		return
+	}
+	for _, matchup := range matchups {
+		fmt.Fprintf(w, "  - %s: %s\n", p.Type(matchup.Type), highlight(strings.Join(matchup.Members, ", ")))
+	}
+}
+
+func styledTypes(p style.Palette, types []string) string {
+	styled := make([]string, len(types))
+	for i, typeName := range types {
+		styled[i] = p.Type(typeName)
+	}
+	return strings.Join(styled, ", ")
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+func (r teamResult) table() ([]string, [][]string) {
+	var rows [][]string
+	for _, matchup := range r.SharedWeaknesses {
+		rows = append(rows, []string{"weakness", matchup.Type, strings.Join(matchup.Members, ", ")})
+	}
+	for _, matchup := range r.Immunities {
+		rows = append(rows, []string{"immunity", matchup.Type, strings.Join(matchup.Members, ", ")})
+	}
+	for _, typeName := range r.Uncovered {
+		rows = append(rows, []string{"uncovered", typeName, ""})
+	}
+	if r.Suggestion != "" {
+		rows = append(rows, []string{"suggestion", r.Suggestion, ""})
+	}
+	return []string{"kind", "type", "members"}, rows
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+func TestCommandTeamAnalyze(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	var b strings.Builder
+	cfg.Out = &b
+
+	if err := runCommand(cfg, "team analyze"); err == nil {
+		t.Error("expected analyzing an empty collection to fail")
+	}
+
+	cfg.Collection.Add(trainer.Pokemon{Species: "caterpie", Nickname: "Cat", Level: 5, Moves: []string{"tackle", "string-shot"}})
+	if err := runCommand(cfg, "team analyze Cat metapod"); err != nil {
+		t.Fatalf("team analyze failed: %v", err)
+	}
+	expected := "Team:\n" +
+		" - Cat (bug), attacks with normal\n" +
+		" - metapod (bug), attacks with bug\n" +
+		"Shared weaknesses:\n" +
+		"  - fire: Cat, metapod\n" +
+		"  - flying: Cat, metapod\n" +
+		"  - rock: Cat, metapod\n" +
+		"Immunities:\n" +
+		"  (none)\n" +
+		"Not hit super effectively:\n" +
+		"  normal, fire, water, electric, ice, fighting, poison, ground, flying, bug, rock, ghost, dragon, steel, fairy\n" +
+		"Suggestion: a ground type Pokémon would fill the most gaps\n"
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+
+	// The seventh catch goes to a box, so it is analyzed by default but
+	// not with --party.
+	for range 5 {
+		cfg.Collection.Add(trainer.Pokemon{Species: "caterpie", Level: 5})
+	}
+	cfg.Collection.Add(trainer.Pokemon{Species: "metapod", Nickname: "Boxed", Level: 7})
+
+	b.Reset()
+	if err := runCommand(cfg, "team analyze --output json"); err != nil {
+		t.Fatalf("team analyze failed: %v", err)
+	}
+	if !strings.Contains(b.String(), `"name": "Cat"`) || !strings.Contains(b.String(), `"name": "Boxed"`) {
+		t.Errorf("expected every caught Pokémon to be analyzed by default, got:\n%s", b.String())
+	}
+
+	b.Reset()
+	if err := runCommand(cfg, "team analyze --party --output json"); err != nil {
+		t.Fatalf("team analyze --party failed: %v", err)
+	}
+	if !strings.Contains(b.String(), `"name": "Cat"`) || strings.Contains(b.String(), "Boxed") {
+		t.Errorf("expected only the party with --party, got:\n%s", b.String())
+	}
+	if err := runCommand(cfg, "team analyze --party Cat"); err == nil {
+		t.Error("expected --party with named Pokémon to fail")
+	}
+
+	if err := runCommand(cfg, "team"); err == nil {
+		t.Error("expected team without a subcommand to fail")
+	}
+}
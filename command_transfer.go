@@ -0,0 +1,449 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/showdown"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+const (
+	transferJSON     = "json"
+	transferCSV      = "csv"
+	transferShowdown = "showdown"
+)
+
+var csvHeader = []string{"id", "species", "nickname", "level", "experience", "nature", "ability", "moves", "ivs", "evs", "caught_at", "location"}
+
+type importedPokemon struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Species string `json:"species"`
+	SentTo  string `json:"sent_to"`
+}
+
+type importResult struct {
+	File     string            `json:"file"`
+	Format   string            `json:"format"`
+	Imported []importedPokemon `json:"imported"`
+}
+
+// transferFormat takes the format from --format, or else guesses it from
+// the file extension.
+func transferFormat(path string, flags map[string]string) (string, error) {
+	format, ok := flags["format"]
+	if !ok {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			return transferCSV, nil
+		case ".txt":
+			return transferShowdown, nil
+		}
+		return transferJSON, nil
+	}
+
+	format = strings.ToLower(format)
+	if format != transferJSON && format != transferCSV && format != transferShowdown {
+		return "", fmt.Errorf("unknown format '%s' (expected one of json, csv, showdown)", format)
+	}
+	return format, nil
+}
+
+func commandExport(cfg *Config, args ...string) (result, error) {
+	positional, flags, err := parseFlags(args, map[string]bool{"format": true})
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 1 {
+		return nil, errors.New("usage: export <file> [--format json|csv|showdown]")
+	}
+	path := positional[0]
+	format, err := transferFormat(path, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := encodePokemon(&buf, format, cfg.Collection.Pokemon); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return nil, fmt.Errorf("could not write %s: %w", path, err)
+	}
+
+	return messageResult{Message: fmt.Sprintf("Exported %d Pokémon to %s (%s).", len(cfg.Collection.Pokemon), path, format)}, nil
+}
+
+// commandImport adds every Pokémon in a file to the collection, or none of
+// them if any entry is invalid or conflicts with what is already owned.
+// Imported Pokémon are given new IDs.
+func commandImport(cfg *Config, args ...string) (result, error) {
+	if cfg.Battle != nil {
+		return nil, errInBattle
+	}
+	positional, flags, err := parseFlags(args, map[string]bool{"format": true})
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 1 {
+		return nil, errors.New("usage: import <file> [--format json|csv|showdown]")
+	}
+	path := positional[0]
+	format, err := transferFormat(path, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+	entries, err := decodePokemon(bytes.NewReader(data), format)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s as %s: %w", path, format, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s contains no Pokémon", path)
+	}
+
+	var problems []string
+	species := make([]string, len(entries))
+	nicknames := make(map[string]int)
+	for i := range entries {
+		entry := &entries[i]
+		label := fmt.Sprintf("entry %d (%s)", i+1, entry.Name())
+
+		species[i], err = validateImport(cfg, entry)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", label, err))
+			continue
+		}
+
+		if entry.Nickname != "" {
+			key := strings.ToLower(entry.Nickname)
+			if other, err := cfg.Collection.Find(entry.Nickname); err == nil && strings.EqualFold(other.Nickname, entry.Nickname) {
+				problems = append(problems, fmt.Sprintf("%s: #%d is already called %s", label, other.ID, other.Nickname))
+			} else if previous, ok := nicknames[key]; ok {
+				problems = append(problems, fmt.Sprintf("%s: entry %d is also called %s", label, previous, entry.Nickname))
+			}
+			nicknames[key] = i + 1
+		}
+		if owned := findDuplicate(&cfg.Collection, *entry); owned != nil {
+			problems = append(problems, fmt.Sprintf("%s: already in your collection as #%d", label, owned.ID))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("nothing was imported from %s:\n  %s", path, strings.Join(problems, "\n  "))
+	}
+
+	res := importResult{File: path, Format: format, Imported: make([]importedPokemon, 0, len(entries))}
+	for i, entry := range entries {
+		added, place := cfg.Collection.Add(entry)
+		cfg.Pokedex.MarkCaught(species[i])
+		res.Imported = append(res.Imported, importedPokemon{ID: added.ID, Name: added.Name(), Species: added.Species, SentTo: place.String()})
+	}
+	return res, nil
+}
+
+// validateImport checks an entry against PokeAPI and fills in what the
+// format could not carry. It returns the entry's species for the Pokédex.
+func validateImport(cfg *Config, entry *trainer.Pokemon) (string, error) {
+	entry.Species = strings.ToLower(entry.Species)
+	pokemon, err := cfg.PokeapiClient.GetPokemonDetails(entry.Species)
+	if err != nil {
+		return "", err
+	}
+
+	if entry.Level < 1 || entry.Level > trainer.MaxLevel {
+		return "", fmt.Errorf("level must be from 1 to %d", trainer.MaxLevel)
+	}
+	if entry.Nickname != "" {
+		if err := trainer.ValidateNickname(entry.Nickname); err != nil {
+			return "", err
+		}
+	}
+
+	if entry.Ability != "" {
+		known := false
+		for _, abilityEntry := range pokemon.Abilities {
+			known = known || abilityEntry.Ability.Name == entry.Ability
+		}
+		if !known {
+			return "", fmt.Errorf("%s cannot have the ability %s", pokemon.Name, entry.Ability)
+		}
+	}
+
+	if len(entry.Moves) > trainer.MaxMoves {
+		return "", fmt.Errorf("a Pokémon can know at most %d moves", trainer.MaxMoves)
+	}
+	for i, move := range entry.Moves {
+		learnable := false
+		for _, moveEntry := range pokemon.Moves {
+			learnable = learnable || moveEntry.Move.Name == move
+		}
+		if !learnable {
+			return "", fmt.Errorf("%s cannot learn %s", pokemon.Name, move)
+		}
+		if slices.Contains(entry.Moves[:i], move) {
+			return "", fmt.Errorf("%s is listed twice", move)
+		}
+	}
+
+	if entry.Nature.Name != "" {
+		if entry.Nature, err = lookupNature(cfg, entry.Nature.Name); err != nil {
+			return "", err
+		}
+	}
+
+	totalEVs := 0
+	for stat, value := range entry.IVs {
+		if !slices.Contains(trainer.StatNames, stat) {
+			return "", fmt.Errorf("unknown stat %s", stat)
+		}
+		if err := trainer.ValidateIV(value); err != nil {
+			return "", err
+		}
+	}
+	for stat, value := range entry.EVs {
+		if !slices.Contains(trainer.StatNames, stat) {
+			return "", fmt.Errorf("unknown stat %s", stat)
+		}
+		if err := trainer.ValidateEV(value); err != nil {
+			return "", err
+		}
+		totalEVs += value
+	}
+	if totalEVs > trainer.MaxTotalEVs {
+		return "", fmt.Errorf("EVs add up to %d, more than %d", totalEVs, trainer.MaxTotalEVs)
+	}
+
+	curve, err := growthCurve(cfg, pokemon)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case entry.Experience == 0:
+		entry.Experience = curve.Experience(entry.Level)
+	case curve.Level(entry.Experience) != entry.Level:
+		return "", fmt.Errorf("%d experience points is not level %d", entry.Experience, entry.Level)
+	}
+
+	entry.ID = 0
+	if entry.CaughtAt.IsZero() {
+		entry.CaughtAt = time.Now().UTC()
+	}
+	return pokemon.Species.Name, nil
+}
+
+// findDuplicate spots a Pokémon exported from this collection being
+// imported back into it. Only JSON and CSV keep the time of capture.
+func findDuplicate(c *trainer.Collection, entry trainer.Pokemon) *trainer.Pokemon {
+	if entry.CaughtAt.IsZero() {
+		return nil
+	}
+	for i := range c.Pokemon {
+		owned := &c.Pokemon[i]
+		if owned.Species == entry.Species && owned.CaughtAt.Equal(entry.CaughtAt) {
+			return owned
+		}
+	}
+	return nil
+}
+
+func encodePokemon(w io.Writer, format string, pokemon []trainer.Pokemon) error {
+	switch format {
+	case transferCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(csvHeader)
+		for _, p := range pokemon {
+			cw.Write([]string{
+				strconv.Itoa(p.ID),
+				p.Species,
+				p.Nickname,
+				strconv.Itoa(p.Level),
+				strconv.Itoa(p.Experience),
+				p.Nature.Name,
+				p.Ability,
+				strings.Join(p.Moves, ";"),
+				formatStatList(p.IVs),
+				formatStatList(p.EVs),
+				p.CaughtAt.Format(time.RFC3339),
+				p.Location,
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	case transferShowdown:
+		sets := make([]showdown.Set, 0, len(pokemon))
+		for _, p := range pokemon {
+			sets = append(sets, showdown.Set{
+				Species:  p.Species,
+				Nickname: p.Nickname,
+				Ability:  p.Ability,
+				Level:    p.Level,
+				Nature:   p.Nature.Name,
+				IVs:      p.IVs,
+				EVs:      p.EVs,
+				Moves:    p.Moves,
+			})
+		}
+		return showdown.Write(w, sets)
+	}
+
+	data, err := json.MarshalIndent(pokemon, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func decodePokemon(r io.Reader, format string) ([]trainer.Pokemon, error) {
+	switch format {
+	case transferCSV:
+		return decodeCSV(r)
+	case transferShowdown:
+		sets, err := showdown.Read(r)
+		if err != nil {
+			return nil, err
+		}
+		pokemon := make([]trainer.Pokemon, 0, len(sets))
+		for _, set := range sets {
+			p := trainer.Pokemon{
+				Species:  set.Species,
+				Nickname: set.Nickname,
+				Ability:  set.Ability,
+				Level:    set.Level,
+				Nature:   trainer.Nature{Name: set.Nature},
+				IVs:      trainer.Stats{},
+				EVs:      trainer.Stats{},
+				Moves:    set.Moves,
+			}
+			for _, stat := range trainer.StatNames {
+				p.IVs[stat] = showdown.DefaultIV
+				if iv, ok := set.IVs[stat]; ok {
+					p.IVs[stat] = iv
+				}
+				p.EVs[stat] = set.EVs[stat]
+			}
+			pokemon = append(pokemon, p)
+		}
+		return pokemon, nil
+	}
+
+	var pokemon []trainer.Pokemon
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&pokemon); err != nil {
+		return nil, err
+	}
+	return pokemon, nil
+}
+
+func decodeCSV(r io.Reader) ([]trainer.Pokemon, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || !slices.Equal(records[0], csvHeader) {
+		return nil, fmt.Errorf("expected the header %s", strings.Join(csvHeader, ","))
+	}
+
+	pokemon := make([]trainer.Pokemon, 0, len(records)-1)
+	for i, record := range records[1:] {
+		line := i + 2
+		p := trainer.Pokemon{
+			Species:  record[1],
+			Nickname: record[2],
+			Nature:   trainer.Nature{Name: record[5]},
+			Ability:  record[6],
+			Location: record[11],
+		}
+		if p.Level, err = strconv.Atoi(record[3]); err != nil {
+			return nil, fmt.Errorf("line %d: invalid level %q", line, record[3])
+		}
+		if p.Experience, err = strconv.Atoi(record[4]); err != nil {
+			return nil, fmt.Errorf("line %d: invalid experience %q", line, record[4])
+		}
+		if record[7] != "" {
+			p.Moves = strings.Split(record[7], ";")
+		}
+		if p.IVs, err = parseStatList(record[8]); err != nil {
+			return nil, fmt.Errorf("line %d: invalid IVs: %w", line, err)
+		}
+		if p.EVs, err = parseStatList(record[9]); err != nil {
+			return nil, fmt.Errorf("line %d: invalid EVs: %w", line, err)
+		}
+		if record[10] != "" {
+			if p.CaughtAt, err = time.Parse(time.RFC3339, record[10]); err != nil {
+				return nil, fmt.Errorf("line %d: invalid caught_at %q", line, record[10])
+			}
+		}
+		pokemon = append(pokemon, p)
+	}
+	return pokemon, nil
+}
+
+// formatStatList writes stats in StatNames order as "31/31/31/31/31/31".
+func formatStatList(stats trainer.Stats) string {
+	if stats == nil {
+		return ""
+	}
+	values := make([]string, len(trainer.StatNames))
+	for i, stat := range trainer.StatNames {
+		values[i] = strconv.Itoa(stats[stat])
+	}
+	return strings.Join(values, "/")
+}
+
+func parseStatList(s string) (trainer.Stats, error) {
+	if s == "" {
+		return nil, nil
+	}
+	values := strings.Split(s, "/")
+	if len(values) != len(trainer.StatNames) {
+		return nil, fmt.Errorf("expected %d values separated by /", len(trainer.StatNames))
+	}
+	stats := make(trainer.Stats, len(values))
+	for i, value := range values {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		stats[trainer.StatNames[i]] = n
+	}
+	return stats, nil
+}
+
+func (r importResult) writeText(w io.Writer, p style.Palette) {
+	fmt.Fprintf(w, "Imported %d Pokémon from %s:\n", len(r.Imported), r.File)
+	for _, pokemon := range r.Imported {
+		name := pokemon.Species
+		if pokemon.Name != pokemon.Species {
+			name = fmt.Sprintf("%s (%s)", p.Bold(pokemon.Name), pokemon.Species)
+		}
+		fmt.Fprintf(w, " #%-3d %s, sent to %s\n", pokemon.ID, name, pokemon.SentTo)
+	}
+}
+
+func (r importResult) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Imported))
+	for _, pokemon := range r.Imported {
+		rows = append(rows, []string{strconv.Itoa(pokemon.ID), pokemon.Name, pokemon.Species, pokemon.SentTo})
+	}
+	return []string{"id", "name", "species", "sent_to"}, rows
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+func transferTestPokemon() trainer.Pokemon {
+	return trainer.Pokemon{
+		Species:    "caterpie",
+		Nickname:   "Cat",
+		Level:      5,
+		Experience: 130,
+		IVs:        trainer.Stats{"hp": 31, "attack": 0, "defense": 12, "special-attack": 31, "special-defense": 31, "speed": 31},
+		EVs:        trainer.Stats{"hp": 4, "attack": 0, "defense": 0, "special-attack": 0, "special-defense": 0, "speed": 252},
+		Nature:     trainer.Nature{Name: "adamant", Increased: "attack", Decreased: "special-attack"},
+		Ability:    "shield-dust",
+		Moves:      []string{"tackle", "string-shot"},
+		CaughtAt:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Location:   "viridian-forest-area",
+	}
+}
+
+func TestCommandExportImport_RoundTrip(t *testing.T) {
+	for _, format := range []string{"json", "csv", "showdown"} {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "team")
+			exporter := newAreaTestConfig(t)
+			exporter.Collection.Add(transferTestPokemon())
+			if err := runCommand(exporter, "export "+path+" --format "+format); err != nil {
+				t.Fatalf("export failed: %v", err)
+			}
+
+			importer := newAreaTestConfig(t)
+			importer.Collection.Add(trainer.Pokemon{Species: "metapod", Level: 7})
+			if err := runCommand(importer, "import "+path+" --format "+format); err != nil {
+				t.Fatalf("import failed: %v", err)
+			}
+
+			imported, err := importer.Collection.Find("Cat")
+			if err != nil {
+				t.Fatalf("expected Cat to be imported: %v", err)
+			}
+			expected := transferTestPokemon()
+			expected.ID = 2
+			if format == "showdown" {
+				// Showdown sets carry no capture details or exact experience.
+				expected.Experience = 125
+				expected.CaughtAt = imported.CaughtAt
+				expected.Location = ""
+			}
+			if !reflect.DeepEqual(*imported, expected) {
+				t.Errorf("expected %+v, got %+v", expected, *imported)
+			}
+			if !importer.Pokedex.HasCaught("caterpie") {
+				t.Error("expected the imported species to be marked as caught")
+			}
+		})
+	}
+}
+
+func TestCommandImport_AllOrNothing(t *testing.T) {
+	cfg := newAreaTestConfig(t)
+	cfg.Collection.Add(transferTestPokemon())
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "backup.json")
+	if err := runCommand(cfg, "export "+path); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	err := runCommand(cfg, "import "+path)
+	if err == nil || !strings.Contains(err.Error(), "#1 is already called Cat") || !strings.Contains(err.Error(), "already in your collection as #1") {
+		t.Errorf("expected conflicts to be reported, got %v", err)
+	}
+
+	team := "Caterpie\nAbility: Shield Dust\n- Tackle\n\n" +
+		"Metapod\nAbility: Levitate\n- Harden\n\n" +
+		"Metapod\n- Fly\n\n" +
+		"Missingno\n"
+	path = filepath.Join(dir, "team.txt")
+	if err := os.WriteFile(path, []byte(team), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err = runCommand(cfg, "import "+path)
+	if err == nil {
+		t.Fatal("expected an invalid team to be refused")
+	}
+	for _, problem := range []string{
+		"entry 2 (metapod): metapod cannot have the ability levitate",
+		"entry 3 (metapod): metapod cannot learn fly",
+		"entry 4 (missingno): pokemon 'missingno' not found",
+	} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("expected %q in %v", problem, err)
+		}
+	}
+	if len(cfg.Collection.Pokemon) != 1 {
+		t.Errorf("expected nothing to be imported, got %d Pokémon", len(cfg.Collection.Pokemon))
+	}
+
+	if err := runCommand(cfg, "export "+path+" --format xml"); err == nil {
+		t.Error("expected an unknown format to fail")
+	}
+}
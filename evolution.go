@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/evolution"
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+// pendingEvolution waits for the player to confirm or cancel it with the
+// evolve and cancel commands.
+type pendingEvolution struct {
+	ID      int    `json:"id"`
+	Pokemon string `json:"pokemon"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Item    string `json:"item,omitempty"`
+}
+
+// queueEvolution checks whether individual can evolve after trigger and,
+// if so, queues the evolution for confirmation. An individual is only
+// queued once at a time.
+func queueEvolution(cfg *Config, individual *trainer.Pokemon, pokemon pokeapi.Pokemon, trigger evolution.Trigger) (*pendingEvolution, error) {
+	options, err := lookupEvolutions(cfg, pokemon)
+	if err != nil {
+		return nil, err
+	}
+	return options.queue(cfg, individual, trigger), nil
+}
+
+// evolutionOptions holds everything needed to decide on an evolution, so
+// the decision itself can be made without any further lookups.
+type evolutionOptions struct {
+	species string
+	chain   pokeapi.ChainLink
+	// varieties maps each species the pokemon can evolve into to the
+	// pokemon resource that represents it.
+	varieties map[string]string
+}
+
+// lookupEvolutions fetches the evolution chain of pokemon's species and
+// every species it can evolve into.
+func lookupEvolutions(cfg *Config, pokemon pokeapi.Pokemon) (evolutionOptions, error) {
+	species, err := cfg.PokeapiClient.GetPokemonSpecies(pokemon.Species.Name)
+	if err != nil {
+		return evolutionOptions{}, err
+	}
+	options := evolutionOptions{species: species.Name, varieties: map[string]string{}}
+	if species.EvolutionChain.URL == "" {
+		return options, nil
+	}
+
+	chain, err := cfg.PokeapiClient.GetEvolutionChain(pokeapi.ResourceID(species.EvolutionChain.URL))
+	if err != nil {
+		return evolutionOptions{}, err
+	}
+	options.chain = chain.Chain
+
+	link, _ := evolution.Link(chain.Chain, species.Name)
+	for _, next := range link.EvolvesTo {
+		target, err := cfg.PokeapiClient.GetPokemonSpecies(next.Species.Name)
+		if err != nil {
+			return evolutionOptions{}, err
+		}
+		options.varieties[next.Species.Name] = defaultVariety(target)
+	}
+	return options, nil
+}
+
+// queue queues the evolution trigger causes for individual, if any.
+func (o evolutionOptions) queue(cfg *Config, individual *trainer.Pokemon, trigger evolution.Trigger) *pendingEvolution {
+	for _, pending := range cfg.PendingEvolutions {
+		if pending.ID == individual.ID {
+			return nil
+		}
+	}
+
+	trigger.Moves = individual.Moves
+	trigger.TimeOfDay = timeOfDay(time.Now())
+	targets := evolution.Find(o.chain, o.species, trigger)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	pending := pendingEvolution{
+		ID:      individual.ID,
+		Pokemon: individual.Name(),
+		From:    individual.Species,
+		To:      o.varieties[targets[0]],
+		Item:    trigger.Item,
+	}
+	cfg.PendingEvolutions = append(cfg.PendingEvolutions, pending)
+	return &pending
+}
+
+// defaultVariety is the pokemon resource that represents a species.
+func defaultVariety(species pokeapi.PokemonSpecies) string {
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			return variety.Pokemon.Name
+		}
+	}
+	return species.Name
+}
+
+func timeOfDay(t time.Time) string {
+	if hour := t.Hour(); hour >= 6 && hour < 18 {
+		return "day"
+	}
+	return "night"
+}
+
+func (e *pendingEvolution) writeText(w io.Writer, p style.Palette) {
+	if e == nil {
+		return
+	}
+	fmt.Fprintf(w, "What? %s is evolving into %s! Enter %s to continue or %s to stop it.\n",
+		e.Pokemon, p.Bold(e.To), p.Bold("evolve"), p.Bold("cancel"))
+}
+
+func (e *pendingEvolution) table() ([]string, [][]string) {
+	return []string{"id", "pokemon", "from", "to"}, [][]string{{strconv.Itoa(e.ID), e.Pokemon, e.From, e.To}}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/GrahamZiervogel/pokedex/internal/evolution"
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+// growthCurve looks up the experience curve of the species a pokemon
+// resource belongs to.
+func growthCurve(cfg *Config, pokemon pokeapi.Pokemon) (trainer.GrowthCurve, error) {
+	species, err := cfg.PokeapiClient.GetPokemonSpecies(pokemon.Species.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	growthRate, err := cfg.PokeapiClient.GetGrowthRate(species.GrowthRate.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make(map[int]int, len(growthRate.Levels))
+	for _, level := range growthRate.Levels {
+		levels[level.Level] = level.Experience
+	}
+	return trainer.NewGrowthCurve(levels)
+}
+
+// experienceGain reports experience awarded to one individual.
+type experienceGain struct {
+	ID            int               `json:"id"`
+	Pokemon       string            `json:"pokemon"`
+	Experience    int               `json:"experience"`
+	LevelsReached []int             `json:"levels_reached"`
+	Moves         []moveChange      `json:"moves"`
+	Evolution     *pendingEvolution `json:"evolution,omitempty"`
+}
+
+// awardExperience gives lead experience and effort values for a Pokémon
+// it helped defeat or catch. Levelling up teaches new moves and may queue
+// an evolution. It returns nil without a lead.
+func awardExperience(cfg *Config, lead *trainer.Pokemon, defeated pokeapi.Pokemon, level int) (*experienceGain, error) {
+	award, err := prepareExperience(cfg, lead)
+	if err != nil {
+		return nil, err
+	}
+	return award.apply(cfg, defeated, level), nil
+}
+
+// experienceAward holds everything needed to award lead experience, so
+// the award itself cannot fail.
+type experienceAward struct {
+	lead       *trainer.Pokemon
+	leadData   pokeapi.Pokemon
+	curve      trainer.GrowthCurve
+	group      string
+	evolutions evolutionOptions
+}
+
+// prepareExperience looks up the lead's growth curve, learnset and
+// evolutions. It returns nil without a lead.
+func prepareExperience(cfg *Config, lead *trainer.Pokemon) (*experienceAward, error) {
+	if lead == nil {
+		return nil, nil
+	}
+
+	leadData, err := cfg.PokeapiClient.GetPokemonDetails(lead.Species)
+	if err != nil {
+		return nil, err
+	}
+	curve, err := growthCurve(cfg, leadData)
+	if err != nil {
+		return nil, err
+	}
+	group, err := versionGroup(cfg)
+	if err != nil {
+		return nil, err
+	}
+	evolutions, err := lookupEvolutions(cfg, leadData)
+	if err != nil {
+		return nil, err
+	}
+	return &experienceAward{lead: lead, leadData: leadData, curve: curve, group: group, evolutions: evolutions}, nil
+}
+
+func (a *experienceAward) apply(cfg *Config, defeated pokeapi.Pokemon, level int) *experienceGain {
+	if a == nil {
+		return nil
+	}
+	lead := a.lead
+
+	exp := trainer.ExperienceYield(defeated.BaseExperience, level)
+	reached := lead.GainExperience(exp, a.curve)
+	lead.GainEffort(effortYield(defeated))
+
+	gain := &experienceGain{ID: lead.ID, Pokemon: lead.Name(), Experience: exp, LevelsReached: []int{}, Moves: []moveChange{}}
+	if len(reached) == 0 {
+		return gain
+	}
+	gain.LevelsReached = reached
+
+	for _, newLevel := range reached {
+		gain.Moves = append(gain.Moves, teachMoves(lead, movesLearnedAt(a.leadData, a.group, newLevel))...)
+	}
+	gain.Evolution = a.evolutions.queue(cfg, lead, evolution.Trigger{Kind: evolution.TriggerLevelUp, Level: lead.Level})
+	return gain
+}
+
+func (g *experienceGain) writeText(w io.Writer, p style.Palette) {
+	if g == nil {
+		return
+	}
+	fmt.Fprintf(w, "%s gained %d Exp. Points!\n", g.Pokemon, g.Experience)
+	for _, level := range g.LevelsReached {
+		fmt.Fprintf(w, "%s grew to level %s!\n", g.Pokemon, p.Good(strconv.Itoa(level)))
+	}
+	writeMoveChanges(w, g.Pokemon, g.Moves)
+	g.Evolution.writeText(w, p)
+}
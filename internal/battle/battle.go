@@ -0,0 +1,352 @@
+package battle
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	CategoryPhysical = "physical"
+	CategorySpecial  = "special"
+	CategoryStatus   = "status"
+)
+
+type Move struct {
+	Name     string
+	Type     string
+	Category string
+	Power    int
+	// Accuracy is a percentage; 0 means the move never misses.
+	Accuracy  int
+	PP        int
+	MaxPP     int
+	Priority  int
+	CritStage int
+}
+
+// struggle is used when every move is out of PP. It has no type, so it
+// always deals normal damage, and hurts the user.
+var struggle = Move{Name: "struggle", Category: CategoryPhysical, Power: 50}
+
+// Combatant is one Pokémon on the field. Stats are its actual stats at
+// its level, keyed by PokeAPI stat name.
+type Combatant struct {
+	Name  string
+	Level int
+	Types []string
+	Stats map[string]int
+	HP    int
+	Moves []*Move
+}
+
+func (c *Combatant) MaxHP() int {
+	return c.Stats["hp"]
+}
+
+func (c *Combatant) Fainted() bool {
+	return c.HP <= 0
+}
+
+func (c *Combatant) hasPP() bool {
+	for _, move := range c.Moves {
+		if move.PP > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+type Outcome string
+
+const (
+	Ongoing Outcome = "ongoing"
+	// Won means the wild Pokémon fainted.
+	Won Outcome = "won"
+	// Fainted means the player's Pokémon fainted; another may be sent out.
+	Fainted Outcome = "fainted"
+	Escaped Outcome = "escaped"
+)
+
+// Turn is what happened during one action, in order.
+type Turn struct {
+	Log     []string
+	Outcome Outcome
+}
+
+var ErrBattleOver = errors.New("the battle is over")
+
+// Battle is a single wild battle between the player's active Pokémon and
+// a wild one. intn must return a value in [0, n), like math/rand's Intn.
+type Battle struct {
+	Player *Combatant
+	Wild   *Combatant
+	Chart  Chart
+
+	intn           func(n int) int
+	escapeAttempts int
+	over           bool
+}
+
+func New(player, wild *Combatant, chart Chart, intn func(n int) int) *Battle {
+	return &Battle{Player: player, Wild: wild, Chart: chart, intn: intn}
+}
+
+func (b *Battle) Over() bool {
+	return b.over
+}
+
+// End stops the battle, for example after the wild Pokémon is caught.
+func (b *Battle) End() {
+	b.over = true
+}
+
+// Fight uses one of the player's moves; the wild Pokémon picks its own.
+// The faster side acts first, after move priority. With no PP left at all
+// the player's Pokémon struggles instead.
+func (b *Battle) Fight(moveName string) (Turn, error) {
+	if err := b.ready(); err != nil {
+		return Turn{}, err
+	}
+
+	playerMove := &struggle
+	if b.Player.hasPP() {
+		var ok bool
+		playerMove, ok = findMove(b.Player, moveName)
+		if !ok {
+			return Turn{}, fmt.Errorf("%s doesn't know %s", b.Player.Name, moveName)
+		}
+		if playerMove.PP <= 0 {
+			return Turn{}, fmt.Errorf("there's no PP left for %s", playerMove.Name)
+		}
+	}
+	wildMove := b.chooseWildMove()
+
+	var log []string
+	if b.playerFirst(playerMove, wildMove) {
+		b.useMove(b.Player, b.Wild, playerMove, &log)
+		if !b.Wild.Fainted() && !b.Player.Fainted() {
+			b.useMove(b.Wild, b.Player, wildMove, &log)
+		}
+	} else {
+		b.useMove(b.Wild, b.Player, wildMove, &log)
+		if !b.Player.Fainted() && !b.Wild.Fainted() {
+			b.useMove(b.Player, b.Wild, playerMove, &log)
+		}
+	}
+
+	return b.finish(log), nil
+}
+
+// Run tries to flee using the Generation III-IV escape formula. A failed
+// attempt gives the wild Pokémon a free attack.
+func (b *Battle) Run() (Turn, error) {
+	if err := b.ready(); err != nil {
+		return Turn{}, err
+	}
+
+	b.escapeAttempts++
+	playerSpeed, wildSpeed := b.Player.Stats["speed"], max(b.Wild.Stats["speed"], 1)
+	odds := (playerSpeed*128/wildSpeed + 30*b.escapeAttempts) % 256
+	if playerSpeed >= wildSpeed || b.intn(256) < odds {
+		b.over = true
+		return Turn{Log: []string{"Got away safely!"}, Outcome: Escaped}, nil
+	}
+
+	return b.WildTurn([]string{"Can't escape!"})
+}
+
+// Heal restores the player's Pokémon by amount HP, capped at its maximum,
+// and then lets the wild Pokémon attack.
+func (b *Battle) Heal(item string, amount int) (Turn, error) {
+	if err := b.ready(); err != nil {
+		return Turn{}, err
+	}
+	if b.Player.HP >= b.Player.MaxHP() {
+		return Turn{}, fmt.Errorf("%s is already at full HP", b.Player.Name)
+	}
+
+	healed := min(amount, b.Player.MaxHP()-b.Player.HP)
+	b.Player.HP += healed
+	return b.WildTurn([]string{fmt.Sprintf("You used a %s. %s recovered %d HP!", item, b.Player.Name, healed)})
+}
+
+// Switch sends out another Pokémon. Replacing a fainted Pokémon is free;
+// otherwise the wild Pokémon attacks the newcomer.
+func (b *Battle) Switch(next *Combatant) (Turn, error) {
+	if b.over {
+		return Turn{}, ErrBattleOver
+	}
+	if next.Fainted() {
+		return Turn{}, fmt.Errorf("%s has no energy left to battle", next.Name)
+	}
+	if next == b.Player {
+		return Turn{}, fmt.Errorf("%s is already battling", next.Name)
+	}
+
+	previous := b.Player
+	b.Player = next
+	log := []string{fmt.Sprintf("Go! %s!", next.Name)}
+	if previous.Fainted() {
+		return Turn{Log: log, Outcome: Ongoing}, nil
+	}
+	log = append([]string{fmt.Sprintf("%s, come back!", previous.Name)}, log...)
+	return b.WildTurn(log)
+}
+
+// WildTurn lets the wild Pokémon act on its own, such as after a ball
+// fails to hold it. log is what happened earlier in the turn.
+func (b *Battle) WildTurn(log []string) (Turn, error) {
+	if err := b.ready(); err != nil {
+		return Turn{}, err
+	}
+	b.useMove(b.Wild, b.Player, b.chooseWildMove(), &log)
+	return b.finish(log), nil
+}
+
+func (b *Battle) ready() error {
+	if b.over {
+		return ErrBattleOver
+	}
+	if b.Player.Fainted() {
+		return fmt.Errorf("%s has fainted; switch to another Pokémon", b.Player.Name)
+	}
+	return nil
+}
+
+func (b *Battle) finish(log []string) Turn {
+	switch {
+	case b.Wild.Fainted():
+		b.over = true
+		return Turn{Log: log, Outcome: Won}
+	case b.Player.Fainted():
+		return Turn{Log: log, Outcome: Fainted}
+	default:
+		return Turn{Log: log, Outcome: Ongoing}
+	}
+}
+
+func (b *Battle) playerFirst(playerMove, wildMove *Move) bool {
+	if playerMove.Priority != wildMove.Priority {
+		return playerMove.Priority > wildMove.Priority
+	}
+	playerSpeed, wildSpeed := b.Player.Stats["speed"], b.Wild.Stats["speed"]
+	if playerSpeed != wildSpeed {
+		return playerSpeed > wildSpeed
+	}
+	return b.intn(2) == 0
+}
+
+// chooseWildMove picks uniformly among the wild Pokémon's moves with PP
+// left, falling back to Struggle.
+func (b *Battle) chooseWildMove() *Move {
+	var usable []*Move
+	for _, move := range b.Wild.Moves {
+		if move.PP > 0 {
+			usable = append(usable, move)
+		}
+	}
+	if len(usable) == 0 {
+		return &struggle
+	}
+	return usable[b.intn(len(usable))]
+}
+
+func findMove(c *Combatant, name string) (*Move, bool) {
+	for _, move := range c.Moves {
+		if move.Name == name {
+			return move, true
+		}
+	}
+	return nil, false
+}
+
+func (b *Battle) useMove(attacker, defender *Combatant, move *Move, log *[]string) {
+	say := func(format string, args ...any) {
+		*log = append(*log, fmt.Sprintf(format, args...))
+	}
+
+	say("%s used %s!", attacker.Name, move.Name)
+	if move != &struggle {
+		move.PP--
+	}
+
+	if move.Accuracy > 0 && b.intn(100) >= move.Accuracy {
+		say("%s's attack missed!", attacker.Name)
+		return
+	}
+	if move.Category == CategoryStatus || move.Power <= 0 {
+		say("But nothing happened.")
+		return
+	}
+
+	effectiveness := 1.0
+	if move.Type != "" {
+		effectiveness = b.Chart.Effectiveness(move.Type, defender.Types)
+	}
+	if effectiveness == 0 {
+		say("It doesn't affect %s...", defender.Name)
+		return
+	}
+
+	attackStat, defenseStat := "attack", "defense"
+	if move.Category == CategorySpecial {
+		attackStat, defenseStat = "special-attack", "special-defense"
+	}
+	critical := b.intn(critDenominator(move.CritStage)) == 0
+	damage := Damage(DamageInput{
+		Level:         attacker.Level,
+		Power:         move.Power,
+		Attack:        attacker.Stats[attackStat],
+		Defense:       defender.Stats[defenseStat],
+		STAB:          hasType(attacker, move.Type),
+		Effectiveness: effectiveness,
+		Critical:      critical,
+	}, MinRoll+b.intn(MaxRoll-MinRoll+1))
+	defender.HP = max(defender.HP-damage, 0)
+
+	if critical {
+		say("A critical hit!")
+	}
+	if effectiveness > 1 {
+		say("It's super effective!")
+	} else if effectiveness < 1 {
+		say("It's not very effective...")
+	}
+
+	if move == &struggle {
+		recoil := max(attacker.MaxHP()/4, 1)
+		attacker.HP = max(attacker.HP-recoil, 0)
+		say("%s is damaged by recoil!", attacker.Name)
+	}
+
+	if defender.Fainted() {
+		say("%s fainted!", defender.Name)
+	}
+	if attacker.Fainted() {
+		say("%s fainted!", attacker.Name)
+	}
+}
+
+// critDenominator gives the Generation VII+ odds of a critical hit, 1 in
+// n, for a move's critical-hit stage.
+func critDenominator(stage int) int {
+	switch {
+	case stage <= 0:
+		return 24
+	case stage == 1:
+		return 8
+	case stage == 2:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func hasType(c *Combatant, moveType string) bool {
+	for _, t := range c.Types {
+		if t == moveType {
+			return true
+		}
+	}
+	return false
+}
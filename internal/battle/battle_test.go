@@ -0,0 +1,210 @@
+package battle
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func pikachu() *Combatant {
+	return &Combatant{
+		Name:  "Sparky",
+		Level: 10,
+		Types: []string{"electric"},
+		Stats: map[string]int{"hp": 31, "attack": 18, "defense": 13, "special-attack": 17, "special-defense": 15, "speed": 26},
+		HP:    31,
+		Moves: []*Move{
+			{Name: "thunder-shock", Type: "electric", Category: CategorySpecial, Power: 40, Accuracy: 100, PP: 30, MaxPP: 30},
+			{Name: "growl", Type: "normal", Category: CategoryStatus, Accuracy: 100, PP: 40, MaxPP: 40},
+			{Name: "quick-attack", Type: "normal", Category: CategoryPhysical, Power: 40, Accuracy: 100, PP: 1, MaxPP: 30, Priority: 1},
+		},
+	}
+}
+
+func squirtle() *Combatant {
+	return &Combatant{
+		Name:  "Wild squirtle",
+		Level: 8,
+		Types: []string{"water"},
+		Stats: map[string]int{"hp": 27, "attack": 13, "defense": 17, "special-attack": 13, "special-defense": 16, "speed": 12},
+		HP:    27,
+		Moves: []*Move{
+			{Name: "tackle", Type: "normal", Category: CategoryPhysical, Power: 40, Accuracy: 100, PP: 35, MaxPP: 35},
+		},
+	}
+}
+
+// scripted returns the given values in order, and then always 0.
+func scripted(values ...int) func(n int) int {
+	return func(n int) int {
+		if len(values) == 0 {
+			return 0
+		}
+		v := values[0]
+		values = values[1:]
+		return v % n
+	}
+}
+
+func TestFight_TurnOrderAndDamage(t *testing.T) {
+	player, wild := pikachu(), squirtle()
+	// wild move choice, player accuracy, crit, roll, wild accuracy, crit, roll
+	b := New(player, wild, testChart(), scripted(0, 0, 5, 15, 0, 5, 15))
+
+	turn, err := b.Fight("thunder-shock")
+	if err != nil {
+		t.Fatalf("Fight failed: %v", err)
+	}
+
+	expected := []string{
+		"Sparky used thunder-shock!",
+		"It's super effective!",
+		"Wild squirtle used tackle!",
+	}
+	if !reflect.DeepEqual(turn.Log, expected) {
+		t.Errorf("expected log %q, got %q", expected, turn.Log)
+	}
+	// (2*10/5+2)*40*17/16/50+2 = 7, then 10 with STAB and 20 doubled for
+	// the type.
+	if wild.HP != 27-20 {
+		t.Errorf("expected the wild squirtle at 7 HP, got %d", wild.HP)
+	}
+	if player.Moves[0].PP != 29 || wild.Moves[0].PP != 34 {
+		t.Errorf("expected both moves to use 1 PP, got %d and %d", player.Moves[0].PP, wild.Moves[0].PP)
+	}
+	if turn.Outcome != Ongoing {
+		t.Errorf("expected the battle to go on, got %s", turn.Outcome)
+	}
+}
+
+func TestFight_PriorityAndPP(t *testing.T) {
+	player, wild := pikachu(), squirtle()
+	wild.Stats["speed"] = 100
+	b := New(player, wild, testChart(), scripted(0, 0, 5))
+
+	turn, err := b.Fight("quick-attack")
+	if err != nil {
+		t.Fatalf("Fight failed: %v", err)
+	}
+	if turn.Log[0] != "Sparky used quick-attack!" {
+		t.Errorf("expected quick attack to go first despite speed, got %q", turn.Log)
+	}
+
+	if _, err := b.Fight("quick-attack"); err == nil || !strings.Contains(err.Error(), "no PP left") {
+		t.Errorf("expected an out of PP error, got %v", err)
+	}
+	if _, err := b.Fight("surf"); err == nil {
+		t.Error("expected an unknown move to be refused")
+	}
+}
+
+func TestFight_Struggle(t *testing.T) {
+	player, wild := pikachu(), squirtle()
+	for _, move := range player.Moves {
+		move.PP = 0
+	}
+	// wild move choice, then Struggle's crit and roll; it never misses
+	b := New(player, wild, testChart(), scripted(0, 5, 0))
+
+	turn, err := b.Fight("thunder-shock")
+	if err != nil {
+		t.Fatalf("Fight failed: %v", err)
+	}
+	if turn.Log[0] != "Sparky used struggle!" || turn.Log[1] != "Sparky is damaged by recoil!" {
+		t.Errorf("expected Sparky to struggle, got %q", turn.Log)
+	}
+	if player.HP >= player.MaxHP() {
+		t.Error("expected struggle to cause recoil")
+	}
+}
+
+func TestFight_WinAndFaint(t *testing.T) {
+	player, wild := pikachu(), squirtle()
+	wild.HP = 1
+	b := New(player, wild, testChart(), scripted(0, 0, 5, 0))
+
+	turn, err := b.Fight("thunder-shock")
+	if err != nil {
+		t.Fatalf("Fight failed: %v", err)
+	}
+	if turn.Outcome != Won || !b.Over() || turn.Log[len(turn.Log)-1] != "Wild squirtle fainted!" {
+		t.Errorf("expected the wild Pokémon to faint, got %s %q", turn.Outcome, turn.Log)
+	}
+	if _, err := b.Fight("thunder-shock"); err != ErrBattleOver {
+		t.Errorf("expected ErrBattleOver, got %v", err)
+	}
+
+	player, wild = pikachu(), squirtle()
+	player.HP = 1
+	wild.Stats["speed"] = 100
+	b = New(player, wild, testChart(), scripted(0, 0, 5, 0))
+	turn, _ = b.Fight("thunder-shock")
+	if turn.Outcome != Fainted || b.Over() {
+		t.Errorf("expected the player's Pokémon to faint, got %s", turn.Outcome)
+	}
+	if _, err := b.Fight("thunder-shock"); err == nil || !strings.Contains(err.Error(), "switch") {
+		t.Errorf("expected to be told to switch, got %v", err)
+	}
+
+	reserve := pikachu()
+	reserve.Name = "Volt"
+	turn, err = b.Switch(reserve)
+	if err != nil || turn.Log[0] != "Go! Volt!" || len(turn.Log) != 1 {
+		t.Errorf("expected a free switch after fainting, got %q, %v", turn.Log, err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	b := New(pikachu(), squirtle(), testChart(), scripted())
+	turn, err := b.Run()
+	if err != nil || turn.Outcome != Escaped {
+		t.Errorf("expected a faster Pokémon to escape, got %s, %v", turn.Outcome, err)
+	}
+
+	wild := squirtle()
+	wild.Stats["speed"] = 200
+	// 26*128/200 + 30 = 46; a roll of 100 fails.
+	b = New(pikachu(), wild, testChart(), scripted(100, 0, 0, 5))
+	turn, _ = b.Run()
+	if turn.Outcome != Ongoing || turn.Log[0] != "Can't escape!" {
+		t.Errorf("expected the escape to fail, got %s %q", turn.Outcome, turn.Log)
+	}
+}
+
+func TestHeal(t *testing.T) {
+	player := pikachu()
+	b := New(player, squirtle(), testChart(), scripted())
+	if _, err := b.Heal("potion", 20); err == nil {
+		t.Error("expected healing at full HP to be refused")
+	}
+
+	player.HP = 20
+	turn, err := b.Heal("potion", 20)
+	if err != nil {
+		t.Fatalf("Heal failed: %v", err)
+	}
+	if turn.Log[0] != "You used a potion. Sparky recovered 11 HP!" {
+		t.Errorf("unexpected heal log %q", turn.Log)
+	}
+}
+
+func TestBattle_SeededReplay(t *testing.T) {
+	play := func() []string {
+		b := New(pikachu(), squirtle(), testChart(), rand.New(rand.NewSource(7)).Intn)
+		var log []string
+		for !b.Over() {
+			turn, err := b.Fight("thunder-shock")
+			if err != nil {
+				t.Fatalf("Fight failed: %v", err)
+			}
+			log = append(log, turn.Log...)
+		}
+		return log
+	}
+
+	first, second := play(), play()
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected identical battles with the same seed:\n%q\n%q", first, second)
+	}
+}
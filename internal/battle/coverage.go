@@ -0,0 +1,107 @@
+package battle
+
+// Types lists the eighteen battle types in type chart order.
+var Types = []string{
+	"normal", "fire", "water", "electric", "grass", "ice",
+	"fighting", "poison", "ground", "flying", "psychic", "bug",
+	"rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+// Member is one Pokémon of a team as far as type matchups are concerned.
+type Member struct {
+	Name      string
+	Types     []string
+	MoveTypes []string
+}
+
+// Matchup names an attacking type and the members it affects.
+type Matchup struct {
+	Type    string
+	Members []string
+}
+
+// Coverage summarizes where a team stands against the type chart.
+// SharedWeaknesses lists attacking types that are super effective against
+// at least two members, Immunities the attacking types some member takes
+// no damage from, and Uncovered the defending types that none of the
+// team's moves hit super effectively. Suggestion is the type that would
+// best fill those gaps, or empty when no type helps.
+type Coverage struct {
+	SharedWeaknesses []Matchup
+	Immunities       []Matchup
+	Uncovered        []string
+	Suggestion       string
+}
+
+// Analyze works out a team's coverage from the chart. Attacking types
+// missing from the chart are treated as neutral against everything.
+func Analyze(chart Chart, team []Member) Coverage {
+	var coverage Coverage
+	shared := min(2, len(team))
+
+	for _, attacking := range Types {
+		var weak, immune []string
+		for _, member := range team {
+			switch effectiveness := chart.Effectiveness(attacking, member.Types); {
+			case effectiveness > 1:
+				weak = append(weak, member.Name)
+			case effectiveness == 0:
+				immune = append(immune, member.Name)
+			}
+		}
+		if len(weak) > 0 && len(weak) >= shared {
+			coverage.SharedWeaknesses = append(coverage.SharedWeaknesses, Matchup{Type: attacking, Members: weak})
+		}
+		if len(immune) > 0 {
+			coverage.Immunities = append(coverage.Immunities, Matchup{Type: attacking, Members: immune})
+		}
+	}
+
+	for _, defending := range Types {
+		if !coveredBy(chart, team, defending) {
+			coverage.Uncovered = append(coverage.Uncovered, defending)
+		}
+	}
+
+	coverage.Suggestion = suggest(chart, coverage)
+	return coverage
+}
+
+func coveredBy(chart Chart, team []Member, defending string) bool {
+	for _, member := range team {
+		for _, moveType := range member.MoveTypes {
+			if chart.Effectiveness(moveType, []string{defending}) > 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// suggest scores every type as a new single-typed member with moves of its
+// own type: a point for each shared weakness it resists and each uncovered
+// type it hits super effectively, and a point off for each shared weakness
+// it suffers too. Ties go to the type that comes first in the chart.
+func suggest(chart Chart, coverage Coverage) string {
+	best, bestScore := "", 0
+	for _, candidate := range Types {
+		score := 0
+		for _, weakness := range coverage.SharedWeaknesses {
+			switch effectiveness := chart.Effectiveness(weakness.Type, []string{candidate}); {
+			case effectiveness < 1:
+				score++
+			case effectiveness > 1:
+				score--
+			}
+		}
+		for _, defending := range coverage.Uncovered {
+			if chart.Effectiveness(candidate, []string{defending}) > 1 {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	return best
+}
@@ -0,0 +1,61 @@
+package battle
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+)
+
+func TestAnalyze(t *testing.T) {
+	chart := testChart()
+	chart.Add(pokeapi.Type{Name: "fire", DamageRelations: pokeapi.TypeDamageRelations{
+		HalfDamageTo:   []pokeapi.NamedAPIResource{{Name: "fire"}, {Name: "water"}, {Name: "rock"}, {Name: "dragon"}},
+		DoubleDamageTo: []pokeapi.NamedAPIResource{{Name: "grass"}, {Name: "ice"}, {Name: "bug"}, {Name: "steel"}},
+	}})
+	chart.Add(pokeapi.Type{Name: "ground", DamageRelations: pokeapi.TypeDamageRelations{
+		NoDamageTo:     []pokeapi.NamedAPIResource{{Name: "flying"}},
+		HalfDamageTo:   []pokeapi.NamedAPIResource{{Name: "grass"}, {Name: "bug"}},
+		DoubleDamageTo: []pokeapi.NamedAPIResource{{Name: "fire"}, {Name: "electric"}, {Name: "poison"}, {Name: "rock"}, {Name: "steel"}},
+	}})
+
+	team := []Member{
+		{Name: "bulbasaur", Types: []string{"grass", "poison"}, MoveTypes: []string{"normal"}},
+		{Name: "pidgey", Types: []string{"normal", "flying"}, MoveTypes: []string{"normal"}},
+		{Name: "dratini", Types: []string{"dragon"}, MoveTypes: []string{"electric"}},
+	}
+	coverage := Analyze(chart, team)
+
+	expectedWeaknesses := []Matchup{{Type: "ice", Members: []string{"bulbasaur", "pidgey", "dratini"}}}
+	if !reflect.DeepEqual(coverage.SharedWeaknesses, expectedWeaknesses) {
+		t.Errorf("expected shared weaknesses %v, got %v", expectedWeaknesses, coverage.SharedWeaknesses)
+	}
+	expectedImmunities := []Matchup{{Type: "ground", Members: []string{"pidgey"}}}
+	if !reflect.DeepEqual(coverage.Immunities, expectedImmunities) {
+		t.Errorf("expected immunities %v, got %v", expectedImmunities, coverage.Immunities)
+	}
+
+	for _, covered := range []string{"flying", "water"} {
+		for _, uncovered := range coverage.Uncovered {
+			if uncovered == covered {
+				t.Errorf("expected %s to be covered by electric moves", covered)
+			}
+		}
+	}
+	if len(coverage.Uncovered) != len(Types)-2 {
+		t.Errorf("expected %d uncovered types, got %v", len(Types)-2, coverage.Uncovered)
+	}
+
+	// Fire resists ice and hits grass, ice, bug and steel super effectively.
+	if coverage.Suggestion != "fire" {
+		t.Errorf("expected fire to be suggested, got %q", coverage.Suggestion)
+	}
+}
+
+func TestAnalyze_SingleMember(t *testing.T) {
+	coverage := Analyze(testChart(), []Member{{Name: "gyarados", Types: []string{"water", "flying"}}})
+
+	if len(coverage.SharedWeaknesses) != 1 || coverage.SharedWeaknesses[0].Type != "electric" {
+		t.Errorf("expected a lone member's weakness to be reported, got %v", coverage.SharedWeaknesses)
+	}
+}
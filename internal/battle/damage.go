@@ -0,0 +1,93 @@
+package battle
+
+import (
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+)
+
+// Chart maps an attacking type to its multiplier against each defending
+// type. Pairs that are not listed deal normal damage.
+type Chart map[string]map[string]float64
+
+// Add records the attacking side of a type's damage relations.
+func (c Chart) Add(t pokeapi.Type) {
+	row := make(map[string]float64)
+	for _, defender := range t.DamageRelations.NoDamageTo {
+		row[defender.Name] = 0
+	}
+	for _, defender := range t.DamageRelations.HalfDamageTo {
+		row[defender.Name] = 0.5
+	}
+	for _, defender := range t.DamageRelations.DoubleDamageTo {
+		row[defender.Name] = 2
+	}
+	c[t.Name] = row
+}
+
+// Has reports whether the attacking type's relations are known.
+func (c Chart) Has(moveType string) bool {
+	_, ok := c[moveType]
+	return ok
+}
+
+// Effectiveness multiplies the move type's modifier against each of the
+// defender's types.
+func (c Chart) Effectiveness(moveType string, defender []string) float64 {
+	multiplier := 1.0
+	for _, defenderType := range defender {
+		if modifier, ok := c[moveType][defenderType]; ok {
+			multiplier *= modifier
+		}
+	}
+	return multiplier
+}
+
+// DamageInput holds everything the damage formula depends on. Attack and
+// Defense are the stats already chosen for the move's category and
+// adjusted for stat stages.
+type DamageInput struct {
+	Level         int
+	Power         int
+	Attack        int
+	Defense       int
+	STAB          bool
+	Effectiveness float64
+	Critical      bool
+}
+
+const (
+	MinRoll = 85
+	MaxRoll = 100
+)
+
+// Damage applies the Generation VI+ damage formula with a random roll from
+// 85 to 100. Every modifier is applied in the games' order: the roll rounds
+// down, critical hits and STAB round halves down, and a hit that is not
+// resisted entirely deals at least 1.
+func Damage(in DamageInput, roll int) int {
+	if in.Power <= 0 || in.Effectiveness == 0 {
+		return 0
+	}
+
+	damage := (2*in.Level/5+2)*in.Power*in.Attack/max(in.Defense, 1)/50 + 2
+	if in.Critical {
+		damage = applyModifier(damage, 6144)
+	}
+	damage = damage * roll / 100
+	if in.STAB {
+		damage = applyModifier(damage, 6144)
+	}
+	damage = int(float64(damage) * in.Effectiveness)
+
+	return max(damage, 1)
+}
+
+// applyModifier multiplies damage by a modifier in 4096ths, the games'
+// fixed-point unit, rounding a half down rather than up.
+func applyModifier(damage, modifier int) int {
+	return (damage*modifier + 2047) / 4096
+}
+
+// DamageRange returns the lowest and highest damage of the random roll.
+func DamageRange(in DamageInput) (int, int) {
+	return Damage(in, MinRoll), Damage(in, MaxRoll)
+}
@@ -0,0 +1,87 @@
+package battle
+
+import (
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+)
+
+func testChart() Chart {
+	chart := Chart{}
+	chart.Add(pokeapi.Type{Name: "ice", DamageRelations: pokeapi.TypeDamageRelations{
+		HalfDamageTo:   []pokeapi.NamedAPIResource{{Name: "fire"}, {Name: "water"}, {Name: "ice"}, {Name: "steel"}},
+		DoubleDamageTo: []pokeapi.NamedAPIResource{{Name: "grass"}, {Name: "ground"}, {Name: "flying"}, {Name: "dragon"}},
+	}})
+	chart.Add(pokeapi.Type{Name: "electric", DamageRelations: pokeapi.TypeDamageRelations{
+		NoDamageTo:     []pokeapi.NamedAPIResource{{Name: "ground"}},
+		HalfDamageTo:   []pokeapi.NamedAPIResource{{Name: "grass"}, {Name: "electric"}, {Name: "dragon"}},
+		DoubleDamageTo: []pokeapi.NamedAPIResource{{Name: "flying"}, {Name: "water"}},
+	}})
+	chart.Add(pokeapi.Type{Name: "normal"})
+	return chart
+}
+
+func TestChart_Effectiveness(t *testing.T) {
+	chart := testChart()
+
+	cases := []struct {
+		moveType string
+		defender []string
+		expected float64
+	}{
+		{"ice", []string{"dragon", "ground"}, 4},
+		{"ice", []string{"water", "flying"}, 1},
+		{"electric", []string{"water", "ground"}, 0},
+		{"electric", []string{"grass", "dragon"}, 0.25},
+		{"normal", []string{"ghost"}, 1},
+		{"fairy", []string{"dragon"}, 1},
+	}
+
+	for _, c := range cases {
+		if got := chart.Effectiveness(c.moveType, c.defender); got != c.expected {
+			t.Errorf("%s against %v = %v, expected %v", c.moveType, c.defender, got, c.expected)
+		}
+	}
+}
+
+func TestDamageRange(t *testing.T) {
+	// Bulbapedia's example: a level 75 Glaceon's Ice Fang against Garchomp.
+	in := DamageInput{Level: 75, Power: 65, Attack: 123, Defense: 163, STAB: true, Effectiveness: 4}
+
+	low, high := DamageRange(in)
+	if low != 168 || high != 196 {
+		t.Errorf("expected 168-196 damage, got %d-%d", low, high)
+	}
+
+	in.Critical = true
+	if low, _ := DamageRange(in); low != 244 {
+		t.Errorf("expected a critical hit to deal at least 244, got %d", low)
+	}
+}
+
+func TestDamage_Immune(t *testing.T) {
+	in := DamageInput{Level: 50, Power: 90, Attack: 100, Defense: 100, Effectiveness: 0}
+	if damage := Damage(in, MaxRoll); damage != 0 {
+		t.Errorf("expected no damage against an immune target, got %d", damage)
+	}
+
+	in = DamageInput{Level: 1, Power: 10, Attack: 5, Defense: 300, Effectiveness: 0.25}
+	if damage := Damage(in, MinRoll); damage != 1 {
+		t.Errorf("expected at least 1 damage, got %d", damage)
+	}
+}
+
+func TestApplyModifier(t *testing.T) {
+	cases := []struct {
+		damage, modifier, expected int
+	}{
+		{3, 6144, 4},   // 4.5 rounds down
+		{5, 5325, 7},   // just over 6.5 rounds up
+		{10, 6144, 15}, // exact
+	}
+	for _, c := range cases {
+		if got := applyModifier(c.damage, c.modifier); got != c.expected {
+			t.Errorf("applyModifier(%d, %d) = %d, expected %d", c.damage, c.modifier, got, c.expected)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package battle
+
+const (
+	MinStage = -6
+	MaxStage = 6
+)
+
+// ApplyStage scales a stat by a stat stage from -6 to +6: each stage up
+// adds half the stat, each stage down divides by one more half.
+func ApplyStage(stat, stage int) int {
+	stage = min(max(stage, MinStage), MaxStage)
+	if stage >= 0 {
+		return stat * (2 + stage) / 2
+	}
+	return stat * 2 / (2 - stage)
+}
+
+// CriticalStages drops the stages a critical hit ignores: the attacker's
+// drops and the defender's boosts.
+func CriticalStages(attackStage, defenseStage int) (int, int) {
+	return max(attackStage, 0), min(defenseStage, 0)
+}
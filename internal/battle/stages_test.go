@@ -0,0 +1,23 @@
+package battle
+
+import (
+	"testing"
+)
+
+func TestApplyStage(t *testing.T) {
+	cases := map[int]int{-6: 25, -2: 50, -1: 66, 0: 100, 1: 150, 2: 200, 6: 400, 9: 400}
+	for stage, expected := range cases {
+		if got := ApplyStage(100, stage); got != expected {
+			t.Errorf("ApplyStage(100, %d) = %d, expected %d", stage, got, expected)
+		}
+	}
+}
+
+func TestCriticalStages(t *testing.T) {
+	if attack, defense := CriticalStages(-2, 3); attack != 0 || defense != 0 {
+		t.Errorf("expected a critical hit to ignore both, got %d and %d", attack, defense)
+	}
+	if attack, defense := CriticalStages(2, -1); attack != 2 || defense != -1 {
+		t.Errorf("expected a critical hit to keep favourable stages, got %d and %d", attack, defense)
+	}
+}
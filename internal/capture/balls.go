@@ -0,0 +1,54 @@
+package capture
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Ball is a kind of Poké Ball with a fixed catch modifier. Balls whose
+// bonus depends on the battle, such as the Net Ball, are not supported.
+type Ball struct {
+	Name          string
+	Bonus         float64
+	AlwaysCatches bool
+}
+
+var balls = map[string]Ball{
+	"poke-ball":    {Name: "poke-ball", Bonus: 1},
+	"great-ball":   {Name: "great-ball", Bonus: 1.5},
+	"ultra-ball":   {Name: "ultra-ball", Bonus: 2},
+	"master-ball":  {Name: "master-ball", AlwaysCatches: true},
+	"premier-ball": {Name: "premier-ball", Bonus: 1},
+	"luxury-ball":  {Name: "luxury-ball", Bonus: 1},
+	"heal-ball":    {Name: "heal-ball", Bonus: 1},
+	"cherish-ball": {Name: "cherish-ball", Bonus: 1},
+}
+
+// LookupBall returns the ball with the given PokeAPI item name.
+func LookupBall(name string) (Ball, error) {
+	ball, ok := balls[name]
+	if !ok {
+		return Ball{}, fmt.Errorf("%s is not a Poké Ball you can throw (expected one of %s)", name, strings.Join(BallNames(), ", "))
+	}
+	return ball, nil
+}
+
+func BallNames() []string {
+	names := make([]string, 0, len(balls))
+	for name := range balls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Throw attempts a catch with ball under rule. The Master Ball skips the
+// formula entirely.
+func Throw(rule Rule, ball Ball, in Input, intn func(n int) int) Result {
+	if ball.AlwaysCatches {
+		return Result{Shakes: 3, Caught: true}
+	}
+	in.BallBonus = ball.Bonus
+	return rule.Attempt(in, intn)
+}
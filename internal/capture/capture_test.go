@@ -0,0 +1,133 @@
+package capture
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func catchRate(rule Rule, in Input, seed int64) float64 {
+	rng := rand.New(rand.NewSource(seed))
+	const attempts = 20000
+	caught := 0
+	for i := 0; i < attempts; i++ {
+		if rule.Attempt(in, rng.Intn).Caught {
+			caught++
+		}
+	}
+	return float64(caught) / attempts
+}
+
+func TestLookup(t *testing.T) {
+	for _, name := range []string{"gen3", "gen6"} {
+		rule, err := Lookup(name)
+		if err != nil {
+			t.Fatalf("Lookup(%s) failed: %v", name, err)
+		}
+		if rule.Name() != name {
+			t.Errorf("expected rule named %s, got %s", name, rule.Name())
+		}
+	}
+
+	if _, err := Lookup("gen99"); err == nil {
+		t.Error("expected error for unknown rules")
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	if status, err := ParseStatus("paralysis"); err != nil || status != StatusParalysis {
+		t.Errorf("expected paralysis, got %q, %v", status, err)
+	}
+	if _, err := ParseStatus(""); err == nil {
+		t.Error("expected an empty status to be rejected")
+	}
+}
+
+func TestAttempt_GuaranteedCatch(t *testing.T) {
+	in := Input{CaptureRate: 255, MaxHP: 20, CurrentHP: 1, BallBonus: 2}
+
+	for _, name := range Names() {
+		rule, _ := Lookup(name)
+		res := rule.Attempt(in, func(n int) int { return n - 1 })
+		if !res.Caught || res.Shakes != 3 {
+			t.Errorf("%s: expected a guaranteed catch, got %+v", name, res)
+		}
+	}
+}
+
+func TestAttempt_ShakeCount(t *testing.T) {
+	rule, _ := Lookup("gen3")
+	in := Input{CaptureRate: 45, MaxHP: 100, CurrentHP: 100, BallBonus: 1}
+
+	rolls := []int{0, 0, 65535}
+	next := 0
+	res := rule.Attempt(in, func(n int) int {
+		roll := rolls[next]
+		next++
+		return roll
+	})
+
+	if res.Caught || res.Shakes != 2 {
+		t.Errorf("expected two shakes and an escape, got %+v", res)
+	}
+}
+
+func TestAttempt_Gen3Odds(t *testing.T) {
+	rule, _ := Lookup("gen3")
+
+	// A full-HP capture rate 45 Pokémon in a Poké Ball: a = 15, so each
+	// shake check passes with b/65536 = 32274/65536 and all four ≈ 5.9%.
+	rate := catchRate(rule, Input{CaptureRate: 45, MaxHP: 100, CurrentHP: 100, BallBonus: 1}, 1)
+	if rate < 0.05 || rate > 0.068 {
+		t.Errorf("expected roughly 5.9%% catch rate, got %.4f", rate)
+	}
+
+	weakened := catchRate(rule, Input{CaptureRate: 45, MaxHP: 100, CurrentHP: 1, BallBonus: 1, Status: StatusSleep}, 2)
+	if weakened <= rate*3 {
+		t.Errorf("expected low HP and sleep to greatly improve odds, got %.4f vs %.4f", weakened, rate)
+	}
+}
+
+func TestAttempt_Gen6SleepBonus(t *testing.T) {
+	gen3, _ := Lookup("gen3")
+	gen6, _ := Lookup("gen6")
+	in := Input{CaptureRate: 45, MaxHP: 100, CurrentHP: 50, BallBonus: 1, Status: StatusSleep}
+
+	if catchRate(gen6, in, 3) <= catchRate(gen3, in, 3) {
+		t.Error("expected gen6's 2.5x sleep bonus to beat gen3's 2x")
+	}
+}
+
+func TestThrow_Balls(t *testing.T) {
+	rule, _ := Lookup("gen6")
+	in := Input{CaptureRate: 3, MaxHP: 100, CurrentHP: 100}
+	alwaysFail := func(n int) int { return n - 1 }
+
+	master, err := LookupBall("master-ball")
+	if err != nil {
+		t.Fatalf("LookupBall failed: %v", err)
+	}
+	if res := Throw(rule, master, in, alwaysFail); !res.Caught {
+		t.Error("expected the Master Ball to always catch")
+	}
+
+	poke, _ := LookupBall("poke-ball")
+	ultra, _ := LookupBall("ultra-ball")
+	in.CaptureRate = 45
+	throwRate := func(ball Ball) float64 {
+		rng := rand.New(rand.NewSource(4))
+		caught := 0
+		for i := 0; i < 20000; i++ {
+			if Throw(rule, ball, in, rng.Intn).Caught {
+				caught++
+			}
+		}
+		return float64(caught) / 20000
+	}
+	if throwRate(ultra) <= throwRate(poke) {
+		t.Error("expected an Ultra Ball to beat a Poké Ball")
+	}
+
+	if _, err := LookupBall("potion"); err == nil {
+		t.Error("expected a potion not to be a ball")
+	}
+}
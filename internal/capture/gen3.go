@@ -0,0 +1,39 @@
+package capture
+
+import (
+	"math"
+)
+
+// gen3Rule is the formula from Ruby/Sapphire through Platinum/HGSS: four
+// shake checks, with sleep and freeze doubling the odds.
+type gen3Rule struct{}
+
+func init() {
+	register(gen3Rule{})
+}
+
+func (gen3Rule) Name() string {
+	return "gen3"
+}
+
+func (gen3Rule) Attempt(in Input, intn func(n int) int) Result {
+	a := modifiedCatchRate(in, func(status Status) float64 {
+		switch status {
+		case StatusSleep, StatusFreeze:
+			return 2
+		case StatusParalysis, StatusBurn, StatusPoison:
+			return 1.5
+		}
+		return 1
+	})
+
+	if a >= 255 {
+		return Result{Shakes: 3, Caught: true}
+	}
+	if a < 1 {
+		a = 1
+	}
+
+	threshold := math.Floor(1048560 / math.Sqrt(math.Sqrt(16711680/a)))
+	return shakeChecks(threshold, 4, intn)
+}
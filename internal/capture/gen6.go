@@ -0,0 +1,39 @@
+package capture
+
+import (
+	"math"
+)
+
+// gen6Rule is the formula used from X/Y onward: sleep and freeze are worth
+// 2.5x and the shake threshold curve is 65536 / (255/a)^(3/16).
+type gen6Rule struct{}
+
+func init() {
+	register(gen6Rule{})
+}
+
+func (gen6Rule) Name() string {
+	return "gen6"
+}
+
+func (gen6Rule) Attempt(in Input, intn func(n int) int) Result {
+	a := modifiedCatchRate(in, func(status Status) float64 {
+		switch status {
+		case StatusSleep, StatusFreeze:
+			return 2.5
+		case StatusParalysis, StatusBurn, StatusPoison:
+			return 1.5
+		}
+		return 1
+	})
+
+	if a >= 255 {
+		return Result{Shakes: 3, Caught: true}
+	}
+	if a < 1 {
+		a = 1
+	}
+
+	threshold := math.Floor(65536 / math.Pow(255/a, 0.1875))
+	return shakeChecks(threshold, 4, intn)
+}
@@ -0,0 +1,201 @@
+// Package cassette records HTTP exchanges to a directory and replays them
+// later, so a session can run without network access and tests see the
+// same responses every time. Each exchange is one JSON file keyed by the
+// request method and URL; request bodies are not part of the key.
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrNotRecorded is returned by a Replayer for a request with no recording.
+var ErrNotRecorded = errors.New("cassette: no recorded response")
+
+// maxNameLength caps the readable part of a cassette file name.
+const maxNameLength = 80
+
+// exchange is the on-disk form of one recorded response. Bodies that are
+// not valid UTF-8, such as sprites, are stored base64-encoded.
+type exchange struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Status       int         `json:"status"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that passes requests on to Next and
+// saves every response it gets back, error statuses included, to Dir.
+type Recorder struct {
+	Dir  string
+	Next http.RoundTripper
+}
+
+// NewRecorder returns a Recorder that saves to dir the responses from
+// http.DefaultTransport.
+func NewRecorder(dir string) *Recorder {
+	return &Recorder{Dir: dir, Next: http.DefaultTransport}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := r.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("cassette: error reading response from %s: %w", req.URL, err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	ex := exchange{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Status: resp.StatusCode,
+		Header: resp.Header,
+		Body:   string(body),
+	}
+	if !utf8.Valid(body) {
+		ex.Body = base64.StdEncoding.EncodeToString(body)
+		ex.BodyEncoding = "base64"
+	}
+	if err := r.save(ex); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) save(ex exchange) error {
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+
+	data, err := json.MarshalIndent(ex, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cassette: error encoding %s: %w", ex.URL, err)
+	}
+
+	// Write to a temporary file first so an interrupted session never
+	// leaves a half-written recording behind.
+	path := filepath.Join(r.Dir, fileName(ex.Method, ex.URL))
+	tmp, err := os.CreateTemp(r.Dir, ".recording-*")
+	if err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("cassette: error writing %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("cassette: error writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("cassette: %w", err)
+	}
+	return nil
+}
+
+// Replayer is an http.RoundTripper that answers requests only from the
+// recordings in Dir. It never touches the network.
+type Replayer struct {
+	Dir string
+}
+
+// NewReplayer returns a Replayer that answers from the recordings in dir.
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{Dir: dir}
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	url := req.URL.String()
+	path := filepath.Join(r.Dir, fileName(req.Method, url))
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w in %s", ErrNotRecorded, r.Dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+
+	var ex exchange
+	if err := json.Unmarshal(data, &ex); err != nil {
+		return nil, fmt.Errorf("cassette: error decoding %s: %w", path, err)
+	}
+	if ex.Method != req.Method || ex.URL != url {
+		return nil, fmt.Errorf("cassette: %s holds %s %s, not %s %s", path, ex.Method, ex.URL, req.Method, url)
+	}
+
+	body := []byte(ex.Body)
+	if ex.BodyEncoding == "base64" {
+		body, err = base64.StdEncoding.DecodeString(ex.Body)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: error decoding body in %s: %w", path, err)
+		}
+	}
+
+	header := ex.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", ex.Status, http.StatusText(ex.Status)),
+		StatusCode:    ex.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// fileName turns a request into a file name that is readable at a glance
+// and still unique, such as "pokeapi.co_api_v2_pokemon_pikachu-1a2b3c4d.json".
+func fileName(method, url string) string {
+	sum := sha256.Sum256([]byte(method + " " + url))
+
+	readable := url
+	if _, rest, ok := strings.Cut(url, "://"); ok {
+		readable = rest
+	}
+	if method != http.MethodGet {
+		readable = method + "_" + readable
+	}
+	readable = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, strings.TrimSuffix(readable, "/"))
+	if len(readable) > maxNameLength {
+		readable = readable[:maxNameLength]
+	}
+
+	return readable + "-" + hex.EncodeToString(sum[:4]) + ".json"
+}
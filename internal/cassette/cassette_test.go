@@ -0,0 +1,104 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	server := pokeapitest.New().Start()
+
+	recording := pokeapi.NewClient(5*time.Second, time.Minute)
+	recording.SetBaseURL(server.URL)
+	recording.SetTransport(NewRecorder(dir))
+
+	want, err := recording.GetPokemonDetails("pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemonDetails while recording: %v", err)
+	}
+	if _, err := recording.GetLocationAreaDetails("nowhere"); err == nil {
+		t.Fatal("expected a not-found error while recording")
+	}
+	server.Close()
+
+	replaying := pokeapi.NewClient(5*time.Second, time.Minute)
+	replaying.SetBaseURL(server.URL)
+	replaying.SetTransport(NewReplayer(dir))
+
+	got, err := replaying.GetPokemonDetails("pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemonDetails while replaying: %v", err)
+	}
+	if got.ID != want.ID || got.Name != want.Name || len(got.Stats) != len(want.Stats) {
+		t.Errorf("replayed %+v, want %+v", got, want)
+	}
+
+	_, err = replaying.GetLocationAreaDetails("nowhere")
+	if err == nil || !strings.Contains(err.Error(), "location area 'nowhere' not found") {
+		t.Errorf("expected the recorded not-found error, got %v", err)
+	}
+}
+
+func TestReplayMiss(t *testing.T) {
+	dir := t.TempDir()
+	client := pokeapi.NewClient(5*time.Second, time.Minute)
+	client.SetBaseURL("http://pokeapi.invalid/api/v2")
+	client.SetTransport(NewReplayer(dir))
+
+	_, err := client.GetPokemonDetails("pikachu")
+	if !errors.Is(err, ErrNotRecorded) {
+		t.Fatalf("expected ErrNotRecorded, got %v", err)
+	}
+	if !strings.Contains(err.Error(), dir) {
+		t.Errorf("expected the error to name the cassette directory, got %v", err)
+	}
+}
+
+func TestRecordBinaryBody(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	dir := t.TempDir()
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/sprites/pokemon/25.png", nil)
+	resp, err := NewRecorder(dir).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip while recording: %v", err)
+	}
+	png, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	resp, err = NewReplayer(dir).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip while replaying: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if len(png) == 0 || !bytes.Equal(body, png) {
+		t.Errorf("replayed %d bytes, want the original %d", len(body), len(png))
+	}
+	if resp.Header.Get("Content-Type") != "image/png" {
+		t.Errorf("expected the recorded Content-Type, got %q", resp.Header.Get("Content-Type"))
+	}
+}
+
+func TestFileName(t *testing.T) {
+	name := fileName(http.MethodGet, "https://pokeapi.co/api/v2/pokemon/pikachu")
+	if !strings.HasPrefix(name, "pokeapi.co_api_v2_pokemon_pikachu-") || !strings.HasSuffix(name, ".json") {
+		t.Errorf("unexpected file name %q", name)
+	}
+
+	first := fileName(http.MethodGet, "https://pokeapi.co/api/v2/location-area?offset=0&limit=20")
+	second := fileName(http.MethodGet, "https://pokeapi.co/api/v2/location-area?offset=0_limit=20")
+	if first == second {
+		t.Errorf("expected distinct file names for distinct URLs, both were %q", first)
+	}
+}
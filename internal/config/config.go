@@ -0,0 +1,218 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/capture"
+)
+
+// Settings holds every user-tunable option. Values are layered in order of
+// increasing precedence: Defaults, the config file, POKEDEX_* environment
+// variables, then command-line flags.
+type Settings struct {
+	BaseURL       string
+	HTTPTimeout   time.Duration
+	CacheInterval time.Duration
+	SavePath      string
+	DataDir       string
+	Language      string
+	GameVersion   string
+	Output        string
+	Color         bool
+	CatchRules    string
+	// Seed is nil unless a seed was given, in which case even 0 is used
+	// as is rather than picking one from the clock.
+	Seed *int64
+}
+
+type setting struct {
+	env string
+	set func(s *Settings, value string) error
+}
+
+// settings maps config file keys to their environment variable and parser.
+// Keys inside a [section] are written as "section.key".
+var settings = map[string]setting{
+	"base_url": {
+		env: "POKEDEX_BASE_URL",
+		set: func(s *Settings, value string) error {
+			s.BaseURL = strings.TrimSuffix(value, "/")
+			return nil
+		},
+	},
+	"http.timeout": {
+		env: "POKEDEX_HTTP_TIMEOUT",
+		set: func(s *Settings, value string) error {
+			return setDuration(&s.HTTPTimeout, value)
+		},
+	},
+	"cache.interval": {
+		env: "POKEDEX_CACHE_INTERVAL",
+		set: func(s *Settings, value string) error {
+			return setDuration(&s.CacheInterval, value)
+		},
+	},
+	"save_path": {
+		env: "POKEDEX_SAVE_PATH",
+		set: func(s *Settings, value string) error {
+			s.SavePath = expandHome(value)
+			return nil
+		},
+	},
+	"data_dir": {
+		env: "POKEDEX_DATA_DIR",
+		set: func(s *Settings, value string) error {
+			s.DataDir = expandHome(value)
+			return nil
+		},
+	},
+	"language": {
+		env: "POKEDEX_LANGUAGE",
+		set: func(s *Settings, value string) error {
+			s.Language = strings.ToLower(value)
+			return nil
+		},
+	},
+	"game_version": {
+		env: "POKEDEX_GAME_VERSION",
+		set: func(s *Settings, value string) error {
+			s.GameVersion = strings.ToLower(value)
+			return nil
+		},
+	},
+	"output": {
+		env: "POKEDEX_OUTPUT",
+		set: func(s *Settings, value string) error {
+			s.Output = strings.ToLower(value)
+			return nil
+		},
+	},
+	"catch_rules": {
+		env: "POKEDEX_CATCH_RULES",
+		set: func(s *Settings, value string) error {
+			rule, err := capture.Lookup(strings.ToLower(value))
+			if err != nil {
+				return err
+			}
+			s.CatchRules = rule.Name()
+			return nil
+		},
+	},
+	"seed": {
+		env: "POKEDEX_SEED",
+		set: func(s *Settings, value string) error {
+			seed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("expected an integer, got '%s'", value)
+			}
+			s.Seed = &seed
+			return nil
+		},
+	},
+	"color": {
+		env: "POKEDEX_COLOR",
+		set: func(s *Settings, value string) error {
+			color, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("expected true or false, got '%s'", value)
+			}
+			s.Color = color
+			return nil
+		},
+	},
+}
+
+func Defaults() Settings {
+	savePath, dataDir := "", ""
+	if dir, err := os.UserConfigDir(); err == nil {
+		savePath = filepath.Join(dir, "pokedex", "save.json")
+		dataDir = filepath.Join(dir, "pokedex", "data")
+	}
+
+	return Settings{
+		BaseURL:       "https://pokeapi.co/api/v2",
+		HTTPTimeout:   5 * time.Second,
+		CacheInterval: 5 * time.Minute,
+		SavePath:      savePath,
+		DataDir:       dataDir,
+		Language:      "en",
+		Output:        "text",
+		Color:         true,
+		CatchRules:    "gen6",
+	}
+}
+
+// DefaultPath is where the config file is looked for when neither
+// --config nor POKEDEX_CONFIG names one.
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pokedex", "config.toml")
+}
+
+// Set assigns a single setting by its config file key.
+func (s *Settings) Set(key, value string) error {
+	entry, ok := settings[key]
+	if !ok {
+		return fmt.Errorf("unknown setting '%s' (known settings: %s)", key, strings.Join(Keys(), ", "))
+	}
+	if err := entry.set(s, value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	return nil
+}
+
+// ApplyEnv overrides settings from POKEDEX_* environment variables.
+func (s *Settings) ApplyEnv(getenv func(string) string) error {
+	for _, key := range Keys() {
+		entry := settings[key]
+		value := getenv(entry.env)
+		if value == "" {
+			continue
+		}
+		if err := s.Set(key, value); err != nil {
+			return fmt.Errorf("%s: %w", entry.env, err)
+		}
+	}
+	return nil
+}
+
+func Keys() []string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func setDuration(target *time.Duration, value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("expected a duration such as \"5s\" or \"2m\", got '%s'", value)
+	}
+	if d <= 0 {
+		return fmt.Errorf("duration must be positive, got '%s'", value)
+	}
+	*target = d
+	return nil
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
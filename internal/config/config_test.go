@@ -0,0 +1,129 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	file := `
+# Self-hosted PokeAPI
+base_url = "http://localhost:8000/api/v2/"
+language = 'ja'
+color = false   # piped into other tools
+
+[http]
+timeout = "10s"
+
+[cache]
+interval = "1h" # keep responses around longer
+`
+	s := Defaults()
+	if err := s.parse(strings.NewReader(file), "config.toml"); err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+
+	if s.BaseURL != "http://localhost:8000/api/v2" {
+		t.Errorf("expected trailing slash to be trimmed from base URL, got '%s'", s.BaseURL)
+	}
+	if s.Language != "ja" {
+		t.Errorf("expected language 'ja', got '%s'", s.Language)
+	}
+	if s.Color {
+		t.Error("expected color to be disabled")
+	}
+	if s.HTTPTimeout != 10*time.Second {
+		t.Errorf("expected 10s timeout, got %v", s.HTTPTimeout)
+	}
+	if s.CacheInterval != time.Hour {
+		t.Errorf("expected 1h cache interval, got %v", s.CacheInterval)
+	}
+	if s.Output != "text" {
+		t.Errorf("expected unset output to keep its default, got '%s'", s.Output)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := map[string]string{
+		"unknown key":      "bogus = 1",
+		"missing equals":   "base_url",
+		"bad duration":     "[http]\ntimeout = \"soon\"",
+		"bad bool":         "color = maybe",
+		"unterminated":     `base_url = "http://`,
+		"trailing garbage": `language = "en" fr`,
+		"bad header":       "[http",
+		"bad catch rules":  "catch_rules = \"gen99\"",
+	}
+
+	for name, file := range cases {
+		s := Defaults()
+		err := s.parse(strings.NewReader(file), "config.toml")
+		if err == nil {
+			t.Errorf("%s: expected an error, got nil", name)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), "config.toml:") {
+			t.Errorf("%s: expected error to carry a line number, got '%s'", name, err.Error())
+		}
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	err := os.WriteFile(path, []byte("output = \"yaml\"\nlanguage = \"de\"\ngame_version = \"red\"\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := Defaults()
+	if err := s.LoadFile(path, false); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	env := map[string]string{"POKEDEX_OUTPUT": "json", "POKEDEX_LANGUAGE": "FR"}
+	if err := s.ApplyEnv(func(key string) string { return env[key] }); err != nil {
+		t.Fatalf("ApplyEnv failed: %v", err)
+	}
+
+	if err := s.Set("output", "table"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if s.Output != "table" {
+		t.Errorf("expected flag to win for output, got '%s'", s.Output)
+	}
+	if s.Language != "fr" {
+		t.Errorf("expected environment to win over file for language, got '%s'", s.Language)
+	}
+	if s.GameVersion != "red" {
+		t.Errorf("expected file value for game version, got '%s'", s.GameVersion)
+	}
+}
+
+func TestLoadFile_Missing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.toml")
+
+	s := Defaults()
+	if err := s.LoadFile(path, true); err != nil {
+		t.Errorf("expected missing optional file to be ignored, got %v", err)
+	}
+	if err := s.LoadFile(path, false); err == nil {
+		t.Error("expected error for missing explicit config file")
+	}
+}
+
+func TestApplyEnv_Invalid(t *testing.T) {
+	s := Defaults()
+	err := s.ApplyEnv(func(key string) string {
+		if key == "POKEDEX_HTTP_TIMEOUT" {
+			return "-1s"
+		}
+		return ""
+	})
+	if err == nil || !strings.Contains(err.Error(), "POKEDEX_HTTP_TIMEOUT") {
+		t.Errorf("expected error naming the environment variable, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadFile applies settings from a config file. A missing file is not an
+// error when optional is set, so the default location can be absent.
+func (s *Settings) LoadFile(path string, optional bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if optional && errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("could not open config file: %w", err)
+	}
+	defer file.Close()
+
+	return s.parse(file, path)
+}
+
+// parse reads the subset of TOML the config file needs: [section] headers,
+// key = value pairs with string, integer or boolean values, and # comments.
+func (s *Settings) parse(r io.Reader, name string) error {
+	scanner := bufio.NewScanner(r)
+	section := ""
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			header, ok := strings.CutSuffix(stripComment(line), "]")
+			if !ok {
+				return fmt.Errorf("%s:%d: unterminated section header", name, lineNumber)
+			}
+			section = strings.TrimSpace(strings.TrimPrefix(header, "["))
+			continue
+		}
+
+		key, rawValue, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("%s:%d: expected key = value", name, lineNumber)
+		}
+		key = strings.TrimSpace(key)
+		if section != "" {
+			key = section + "." + key
+		}
+
+		value, err := parseValue(strings.TrimSpace(rawValue))
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", name, lineNumber, err)
+		}
+
+		if err := s.Set(key, value); err != nil {
+			return fmt.Errorf("%s:%d: %w", name, lineNumber, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func parseValue(raw string) (string, error) {
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		end := closingQuote(raw)
+		if end < 0 {
+			return "", errors.New("unterminated string")
+		}
+		if rest := strings.TrimSpace(raw[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("unexpected text after string: %s", rest)
+		}
+		return strconv.Unquote(raw[:end+1])
+	case strings.HasPrefix(raw, "'"):
+		end := strings.Index(raw[1:], "'")
+		if end < 0 {
+			return "", errors.New("unterminated string")
+		}
+		return raw[1 : end+1], nil
+	}
+
+	value := stripComment(raw)
+	if value == "" {
+		return "", errors.New("missing value")
+	}
+	return value, nil
+}
+
+func closingQuote(raw string) int {
+	for i := 1; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func stripComment(s string) string {
+	if i := strings.Index(s, "#"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
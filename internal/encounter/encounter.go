@@ -0,0 +1,154 @@
+package encounter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+)
+
+// Slot is one row of an area's encounter table: a Pokémon that can appear
+// with the given method, its weight in percent and its level range.
+type Slot struct {
+	Pokemon  string
+	Chance   int
+	MinLevel int
+	MaxLevel int
+}
+
+type Encounter struct {
+	Pokemon string
+	Level   int
+	Method  string
+	Version string
+}
+
+var ErrNoEncounterTable = errors.New("no encounter table")
+
+// Roll simulates one step, cast or surf in an area. The method's rate from
+// encounter_method_rates decides whether anything appears at all; if it
+// does, a slot is chosen weighted by its chance and a level is picked
+// uniformly from its range. found is false when nothing appeared.
+//
+// intn must return a value in [0, n), like math/rand's Intn.
+func Roll(area pokeapi.LocationAreaDetailsResponse, method, version string, intn func(n int) int) (enc Encounter, found bool, err error) {
+	if version == "" {
+		version = DefaultVersion(area, method)
+	}
+
+	rate, ok := MethodRate(area, method, version)
+	slots := Slots(area, method, version)
+	if !ok || len(slots) == 0 {
+		return Encounter{}, false, fmt.Errorf("%w for %s in %s (%s)", ErrNoEncounterTable, method, area.Name, versionLabel(version))
+	}
+
+	if intn(100) >= rate {
+		return Encounter{}, false, nil
+	}
+
+	totalChance := 0
+	for _, slot := range slots {
+		totalChance += slot.Chance
+	}
+
+	pick := intn(totalChance)
+	chosen := slots[len(slots)-1]
+	for _, slot := range slots {
+		if pick < slot.Chance {
+			chosen = slot
+			break
+		}
+		pick -= slot.Chance
+	}
+
+	level := chosen.MinLevel + intn(chosen.MaxLevel-chosen.MinLevel+1)
+
+	return Encounter{
+		Pokemon: chosen.Pokemon,
+		Level:   level,
+		Method:  method,
+		Version: version,
+	}, true, nil
+}
+
+// MethodRate returns the percentage chance that using method in the area
+// triggers an encounter at all.
+func MethodRate(area pokeapi.LocationAreaDetailsResponse, method, version string) (int, bool) {
+	for _, methodRate := range area.EncounterMethodRates {
+		if methodRate.EncounterMethod.Name != method {
+			continue
+		}
+		for _, details := range methodRate.VersionDetails {
+			if details.Version.Name == version {
+				return details.Rate, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Slots collects the encounter table for a method and version. Slots that
+// only apply under special conditions (time of day, swarms, radar and so
+// on) are left out unless the table has nothing else.
+func Slots(area pokeapi.LocationAreaDetailsResponse, method, version string) []Slot {
+	var unconditional, conditional []Slot
+
+	for _, encounter := range area.PokemonEncounters {
+		for _, versionDetails := range encounter.VersionDetails {
+			if versionDetails.Version.Name != version {
+				continue
+			}
+			for _, details := range versionDetails.EncounterDetails {
+				if details.Method.Name != method || details.Chance <= 0 {
+					continue
+				}
+				slot := Slot{
+					Pokemon:  encounter.Pokemon.Name,
+					Chance:   details.Chance,
+					MinLevel: details.MinLevel,
+					MaxLevel: max(details.MaxLevel, details.MinLevel),
+				}
+				if len(details.ConditionValues) > 0 {
+					conditional = append(conditional, slot)
+				} else {
+					unconditional = append(unconditional, slot)
+				}
+			}
+		}
+	}
+
+	if len(unconditional) > 0 {
+		return unconditional
+	}
+	return conditional
+}
+
+// DefaultVersion picks the first game version with a rate for method, for
+// when the player has not configured one.
+func DefaultVersion(area pokeapi.LocationAreaDetailsResponse, method string) string {
+	for _, methodRate := range area.EncounterMethodRates {
+		if methodRate.EncounterMethod.Name != method {
+			continue
+		}
+		for _, details := range methodRate.VersionDetails {
+			return details.Version.Name
+		}
+	}
+	return ""
+}
+
+// Methods lists the encounter methods an area supports.
+func Methods(area pokeapi.LocationAreaDetailsResponse) []string {
+	methods := make([]string, 0, len(area.EncounterMethodRates))
+	for _, methodRate := range area.EncounterMethodRates {
+		methods = append(methods, methodRate.EncounterMethod.Name)
+	}
+	return methods
+}
+
+func versionLabel(version string) string {
+	if version == "" {
+		return "any version"
+	}
+	return version
+}
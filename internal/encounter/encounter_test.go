@@ -0,0 +1,130 @@
+package encounter
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+)
+
+const testArea = `{
+	"name": "viridian-forest-area",
+	"encounter_method_rates": [
+		{"encounter_method": {"name": "walk"}, "version_details": [
+			{"rate": 15, "version": {"name": "red"}},
+			{"rate": 8, "version": {"name": "yellow"}}
+		]},
+		{"encounter_method": {"name": "old-rod"}, "version_details": [
+			{"rate": 100, "version": {"name": "red"}}
+		]}
+	],
+	"pokemon_encounters": [
+		{"pokemon": {"name": "caterpie"}, "version_details": [
+			{"version": {"name": "red"}, "encounter_details": [
+				{"min_level": 3, "max_level": 3, "chance": 20, "method": {"name": "walk"}},
+				{"min_level": 5, "max_level": 5, "chance": 5, "method": {"name": "walk"}}
+			]}
+		]},
+		{"pokemon": {"name": "weedle"}, "version_details": [
+			{"version": {"name": "red"}, "encounter_details": [
+				{"min_level": 4, "max_level": 6, "chance": 75, "method": {"name": "walk"}}
+			]}
+		]},
+		{"pokemon": {"name": "magikarp"}, "version_details": [
+			{"version": {"name": "red"}, "encounter_details": [
+				{"min_level": 5, "max_level": 5, "chance": 100, "method": {"name": "old-rod"}}
+			]}
+		]},
+		{"pokemon": {"name": "pikachu"}, "version_details": [
+			{"version": {"name": "red"}, "encounter_details": [
+				{"min_level": 3, "max_level": 5, "chance": 5, "method": {"name": "walk"},
+				 "condition_values": [{"name": "time-night"}]}
+			]},
+			{"version": {"name": "yellow"}, "encounter_details": [
+				{"min_level": 3, "max_level": 5, "chance": 100, "method": {"name": "walk"}}
+			]}
+		]}
+	]
+}`
+
+func loadTestArea(t *testing.T) pokeapi.LocationAreaDetailsResponse {
+	t.Helper()
+	var area pokeapi.LocationAreaDetailsResponse
+	if err := json.Unmarshal([]byte(testArea), &area); err != nil {
+		t.Fatalf("could not decode test area: %v", err)
+	}
+	return area
+}
+
+func TestSlots(t *testing.T) {
+	area := loadTestArea(t)
+
+	slots := Slots(area, "walk", "red")
+	if len(slots) != 3 {
+		t.Fatalf("expected 3 unconditional walk slots in red, got %d: %v", len(slots), slots)
+	}
+	for _, slot := range slots {
+		if slot.Pokemon == "pikachu" {
+			t.Errorf("expected conditional pikachu slot to be excluded, got %v", slots)
+		}
+	}
+
+	slots = Slots(area, "walk", "yellow")
+	if len(slots) != 1 || slots[0].Pokemon != "pikachu" {
+		t.Errorf("expected only pikachu in yellow, got %v", slots)
+	}
+}
+
+func TestRoll_Distribution(t *testing.T) {
+	area := loadTestArea(t)
+	rng := rand.New(rand.NewSource(1))
+
+	const rolls = 20000
+	counts := map[string]int{}
+	found := 0
+	for i := 0; i < rolls; i++ {
+		enc, ok, err := Roll(area, "walk", "red", rng.Intn)
+		if err != nil {
+			t.Fatalf("Roll failed: %v", err)
+		}
+		if !ok {
+			continue
+		}
+		found++
+		counts[enc.Pokemon]++
+
+		if enc.Pokemon == "weedle" && (enc.Level < 4 || enc.Level > 6) {
+			t.Errorf("weedle level %d outside 4-6", enc.Level)
+		}
+	}
+
+	encounterRate := float64(found) / rolls
+	if encounterRate < 0.13 || encounterRate > 0.17 {
+		t.Errorf("expected roughly 15%% of steps to find something, got %.3f", encounterRate)
+	}
+
+	weedleShare := float64(counts["weedle"]) / float64(found)
+	if weedleShare < 0.70 || weedleShare > 0.80 {
+		t.Errorf("expected weedle in roughly 75%% of encounters, got %.3f", weedleShare)
+	}
+}
+
+func TestRoll_DefaultVersionAndMethods(t *testing.T) {
+	area := loadTestArea(t)
+	rng := rand.New(rand.NewSource(7))
+
+	enc, found, err := Roll(area, "old-rod", "", rng.Intn)
+	if err != nil {
+		t.Fatalf("Roll failed: %v", err)
+	}
+	if !found || enc.Pokemon != "magikarp" || enc.Level != 5 || enc.Version != "red" {
+		t.Errorf("expected a level 5 magikarp in red, got %+v (found=%v)", enc, found)
+	}
+
+	_, _, err = Roll(area, "surf", "red", rng.Intn)
+	if !errors.Is(err, ErrNoEncounterTable) {
+		t.Errorf("expected ErrNoEncounterTable for surfing, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package evolution
+
+import (
+	"slices"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+)
+
+const (
+	TriggerLevelUp = "level-up"
+	TriggerUseItem = "use-item"
+	TriggerTrade   = "trade"
+)
+
+// Trigger describes what just happened to an individual: it levelled up,
+// had an item used on it, or was traded.
+type Trigger struct {
+	Kind      string
+	Level     int
+	Item      string
+	Moves     []string
+	TimeOfDay string
+}
+
+// Find returns the species that species can evolve into under t, in chain
+// order. Evolutions that depend on something the game does not model,
+// such as friendship, held items or the weather, never match.
+func Find(chain pokeapi.ChainLink, species string, t Trigger) []string {
+	link, ok := Link(chain, species)
+	if !ok {
+		return nil
+	}
+
+	var targets []string
+	for _, next := range link.EvolvesTo {
+		for _, detail := range next.EvolutionDetails {
+			if matches(detail, t) {
+				targets = append(targets, next.Species.Name)
+				break
+			}
+		}
+	}
+	return targets
+}
+
+// Link finds species within chain.
+func Link(chain pokeapi.ChainLink, species string) (pokeapi.ChainLink, bool) {
+	if chain.Species.Name == species {
+		return chain, true
+	}
+	for _, next := range chain.EvolvesTo {
+		if link, ok := Link(next, species); ok {
+			return link, true
+		}
+	}
+	return pokeapi.ChainLink{}, false
+}
+
+func matches(d pokeapi.EvolutionDetail, t Trigger) bool {
+	if d.Trigger.Name != t.Kind {
+		return false
+	}
+	if t.Kind == TriggerUseItem && (d.Item == nil || d.Item.Name != t.Item) {
+		return false
+	}
+	if d.MinLevel != nil && t.Level < *d.MinLevel {
+		return false
+	}
+	if d.KnownMove != nil && !slices.Contains(t.Moves, d.KnownMove.Name) {
+		return false
+	}
+	if d.TimeOfDay != "" && d.TimeOfDay != t.TimeOfDay {
+		return false
+	}
+
+	return d.HeldItem == nil &&
+		d.KnownMoveType == nil &&
+		d.Location == nil &&
+		d.PartySpecies == nil &&
+		d.PartyType == nil &&
+		d.TradeSpecies == nil &&
+		d.Gender == nil &&
+		d.MinHappiness == nil &&
+		d.MinBeauty == nil &&
+		d.MinAffection == nil &&
+		d.RelativePhysicalStats == nil &&
+		!d.NeedsOverworldRain &&
+		!d.TurnUpsideDown
+}
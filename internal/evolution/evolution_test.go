@@ -0,0 +1,84 @@
+package evolution
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+)
+
+const eeveeChain = `{
+	"species": {"name": "eevee"},
+	"evolves_to": [
+		{"species": {"name": "vaporeon"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}}]},
+		{"species": {"name": "espeon"}, "evolution_details": [{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "day"}]},
+		{"species": {"name": "sylveon"}, "evolution_details": [{"trigger": {"name": "level-up"}, "known_move_type": {"name": "fairy"}}]}
+	]
+}`
+
+const poliwagChain = `{
+	"species": {"name": "poliwag"},
+	"evolves_to": [{
+		"species": {"name": "poliwhirl"},
+		"evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 25}],
+		"evolves_to": [
+			{"species": {"name": "poliwrath"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}}]},
+			{"species": {"name": "politoed"}, "evolution_details": [
+				{"trigger": {"name": "trade"}, "held_item": {"name": "kings-rock"}},
+				{"trigger": {"name": "use-item"}, "item": {"name": "kings-rock"}}
+			]}
+		]
+	}]
+}`
+
+const haunterChain = `{
+	"species": {"name": "gastly"},
+	"evolves_to": [{
+		"species": {"name": "haunter"},
+		"evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 25}],
+		"evolves_to": [{"species": {"name": "gengar"}, "evolution_details": [{"trigger": {"name": "trade"}}]}]
+	}]
+}`
+
+func parseChain(t *testing.T, data string) pokeapi.ChainLink {
+	t.Helper()
+	var chain pokeapi.ChainLink
+	if err := json.Unmarshal([]byte(data), &chain); err != nil {
+		t.Fatalf("could not parse chain: %v", err)
+	}
+	return chain
+}
+
+func TestFind(t *testing.T) {
+	eevee := parseChain(t, eeveeChain)
+	poliwag := parseChain(t, poliwagChain)
+	gastly := parseChain(t, haunterChain)
+
+	cases := []struct {
+		name     string
+		chain    pokeapi.ChainLink
+		species  string
+		trigger  Trigger
+		expected []string
+	}{
+		{"level below threshold", poliwag, "poliwag", Trigger{Kind: TriggerLevelUp, Level: 24}, nil},
+		{"level at threshold", poliwag, "poliwag", Trigger{Kind: TriggerLevelUp, Level: 25}, []string{"poliwhirl"}},
+		{"item on later stage", poliwag, "poliwhirl", Trigger{Kind: TriggerUseItem, Item: "water-stone"}, []string{"poliwrath"}},
+		{"alternative detail", poliwag, "poliwhirl", Trigger{Kind: TriggerUseItem, Item: "kings-rock"}, []string{"politoed"}},
+		{"trade needing held item", poliwag, "poliwhirl", Trigger{Kind: TriggerTrade}, nil},
+		{"plain trade", gastly, "haunter", Trigger{Kind: TriggerTrade}, []string{"gengar"}},
+		{"wrong item", eevee, "eevee", Trigger{Kind: TriggerUseItem, Item: "fire-stone"}, nil},
+		{"unmodelled conditions", eevee, "eevee", Trigger{Kind: TriggerLevelUp, Level: 100, TimeOfDay: "day"}, nil},
+		{"final stage", gastly, "gengar", Trigger{Kind: TriggerTrade}, nil},
+		{"not in chain", gastly, "pikachu", Trigger{Kind: TriggerLevelUp, Level: 100}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Find(c.chain, c.species, c.trigger); !slices.Equal(got, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
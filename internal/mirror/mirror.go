@@ -0,0 +1,161 @@
+// Package mirror keeps a local copy of PokeAPI resources on disk. Sync
+// downloads them, and Source answers the client's requests from the copy
+// so sessions start faster, work offline and give stable results.
+//
+// A data directory holds one subdirectory per format version. Inside it,
+// every resource kind has an index of its list endpoint plus one file per
+// resource:
+//
+//	<dir>/v1/manifest.json
+//	<dir>/v1/pokemon/index.json
+//	<dir>/v1/pokemon/pikachu.json
+//	<dir>/v1/evolution-chain/10.json
+package mirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// FormatVersion is bumped whenever the layout of the data directory
+// changes, so an old copy is never misread.
+const FormatVersion = 1
+
+// DefaultResources are the kinds the client fetches, in the order Sync
+// downloads them.
+var DefaultResources = []string{
+	"pokemon",
+	"pokemon-species",
+	"location-area",
+	"type",
+	"move",
+	"item",
+	"nature",
+	"growth-rate",
+	"evolution-chain",
+	"version",
+	"pokedex",
+	"generation",
+}
+
+// Manifest records what a data directory holds and how far Sync got.
+type Manifest struct {
+	Format    int                       `json:"format"`
+	BaseURL   string                    `json:"base_url"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+	Resources map[string]ResourceStatus `json:"resources"`
+}
+
+// ResourceStatus is the state of one resource kind. Complete is only set
+// once every resource in the index has been downloaded.
+type ResourceStatus struct {
+	Count    int       `json:"count"`
+	Complete bool      `json:"complete"`
+	SyncedAt time.Time `json:"synced_at,omitempty"`
+}
+
+// entry is one resource of a kind's index, in list endpoint order.
+// Unnamed resources, such as evolution chains, are stored under the ID
+// from their URL and listed without a name.
+type entry struct {
+	Name    string `json:"name"`
+	ID      int    `json:"id,omitempty"`
+	Unnamed bool   `json:"unnamed,omitempty"`
+}
+
+func versionDir(dir string) string {
+	return filepath.Join(dir, "v"+strconv.Itoa(FormatVersion))
+}
+
+func manifestPath(dir string) string {
+	return filepath.Join(versionDir(dir), "manifest.json")
+}
+
+func indexPath(dir, kind string) string {
+	return filepath.Join(versionDir(dir), kind, "index.json")
+}
+
+func resourcePath(dir, kind, name string) string {
+	return filepath.Join(versionDir(dir), kind, name+".json")
+}
+
+// ReadManifest loads the manifest of the data directory dir. The error
+// wraps os.ErrNotExist when dir holds no mirror yet.
+func ReadManifest(dir string) (Manifest, error) {
+	data, err := os.ReadFile(manifestPath(dir))
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("error reading %s: %w", manifestPath(dir), err)
+	}
+	if m.Format != FormatVersion {
+		return Manifest{}, fmt.Errorf("%s has format version %d, expected %d", manifestPath(dir), m.Format, FormatVersion)
+	}
+	if m.Resources == nil {
+		m.Resources = map[string]ResourceStatus{}
+	}
+	return m, nil
+}
+
+func writeManifest(dir string, m Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(manifestPath(dir), append(data, '\n'))
+}
+
+func readIndex(dir, kind string) ([]entry, error) {
+	data, err := os.ReadFile(indexPath(dir, kind))
+	if err != nil {
+		return nil, err
+	}
+
+	var index []entry
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", indexPath(dir, kind), err)
+	}
+	return index, nil
+}
+
+func writeIndex(dir, kind string, index []entry) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(indexPath(dir, kind), append(data, '\n'))
+}
+
+// writeFile replaces path in one step, so an interrupted sync never leaves
+// a half-written file that a later run would mistake for a finished one.
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
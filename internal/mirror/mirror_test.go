@@ -0,0 +1,227 @@
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func newSyncer(baseURL, dir string, resources ...string) *Syncer {
+	return &Syncer{
+		BaseURL:   baseURL,
+		Dir:       dir,
+		Resources: resources,
+		Workers:   4,
+		Backoff:   time.Millisecond,
+		Client:    &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func TestSync(t *testing.T) {
+	fixtures := pokeapitest.New()
+	server := fixtures.Start()
+	defer server.Close()
+	dir := t.TempDir()
+
+	if err := newSyncer(server.URL, dir, "pokemon", "location-area").Sync(context.Background()); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatalf("ReadManifest failed: %v", err)
+	}
+	if manifest.BaseURL != server.URL {
+		t.Errorf("expected base URL %s, got %s", server.URL, manifest.BaseURL)
+	}
+	for kind, count := range map[string]int{"pokemon": 13, "location-area": 4} {
+		status := manifest.Resources[kind]
+		if status.Count != count || !status.Complete {
+			t.Errorf("expected %d complete %s, got %+v", count, kind, status)
+		}
+	}
+	if _, err := os.Stat(resourcePath(dir, "pokemon", "pikachu")); err != nil {
+		t.Errorf("expected pikachu to be downloaded: %v", err)
+	}
+
+	// A second run only fetches the two list endpoints.
+	before := fixtures.Requests()
+	if err := newSyncer(server.URL, dir, "pokemon", "location-area").Sync(context.Background()); err != nil {
+		t.Fatalf("second Sync failed: %v", err)
+	}
+	if got := fixtures.Requests() - before; got != 2 {
+		t.Errorf("expected 2 requests on an up-to-date mirror, got %d", got)
+	}
+}
+
+func TestSync_UnnamedResources(t *testing.T) {
+	server := pokeapitest.New().Start()
+	dir := t.TempDir()
+	if err := newSyncer(server.URL, dir, "evolution-chain").Sync(context.Background()); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	server.Close()
+
+	if _, err := os.Stat(resourcePath(dir, "evolution-chain", "10")); err != nil {
+		t.Errorf("expected the pikachu chain to be stored by ID: %v", err)
+	}
+
+	source, err := NewSource(dir, nil)
+	if err != nil {
+		t.Fatalf("NewSource failed: %v", err)
+	}
+	client := pokeapi.NewClient(5*time.Second, time.Minute)
+	client.SetBaseURL(source.BaseURL())
+	client.SetTransport(source)
+
+	chain, err := client.GetEvolutionChain("10")
+	if err != nil {
+		t.Fatalf("GetEvolutionChain failed: %v", err)
+	}
+	if chain.Chain.Species.Name != "pichu" {
+		t.Errorf("expected the chain to start at pichu, got %s", chain.Chain.Species.Name)
+	}
+
+	resp, err := (&http.Client{Transport: source}).Get(source.BaseURL() + "/evolution-chain?limit=1")
+	if err != nil {
+		t.Fatalf("listing from the mirror failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if strings.Contains(string(body), `"name"`) || !strings.Contains(string(body), "/evolution-chain/1/") {
+		t.Errorf("expected a URL-only list like PokeAPI's, got %s", body)
+	}
+}
+
+func TestSync_UnusableName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"count": 1, "results": [{"url": "http://%s/evolution-chain/../"}]}`, r.Host)
+	}))
+	defer server.Close()
+
+	err := newSyncer(server.URL, t.TempDir(), "evolution-chain").Sync(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "unusable evolution-chain name '..'") {
+		t.Errorf("expected a name derived from the URL to be checked, got %v", err)
+	}
+}
+
+func TestSync_RetriesAndResumes(t *testing.T) {
+	fixtures := pokeapitest.New()
+	fixtures.Fail("/pokemon/25", http.StatusTooManyRequests, 2)
+	fixtures.Fail("/pokemon/4", http.StatusInternalServerError, 0)
+	server := fixtures.Start()
+	defer server.Close()
+	dir := t.TempDir()
+
+	err := newSyncer(server.URL, dir, "pokemon").Sync(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "1 downloads failed") || !strings.Contains(err.Error(), "/pokemon/4") {
+		t.Fatalf("expected charmander to fail, got %v", err)
+	}
+	if _, err := os.Stat(resourcePath(dir, "pokemon", "pikachu")); err != nil {
+		t.Errorf("expected pikachu to be downloaded after retrying: %v", err)
+	}
+	manifest, _ := ReadManifest(dir)
+	if manifest.Resources["pokemon"].Complete {
+		t.Error("expected pokemon to be incomplete after a failure")
+	}
+
+	fixtures.Fail("/pokemon/4", http.StatusInternalServerError, 1)
+	before := fixtures.Requests()
+	if err := newSyncer(server.URL, dir, "pokemon").Sync(context.Background()); err != nil {
+		t.Fatalf("resumed Sync failed: %v", err)
+	}
+	if got := fixtures.Requests() - before; got != 3 {
+		t.Errorf("expected the list, one failure and charmander on resume, got %d requests", got)
+	}
+	manifest, _ = ReadManifest(dir)
+	if !manifest.Resources["pokemon"].Complete {
+		t.Error("expected pokemon to be complete after resuming")
+	}
+}
+
+func TestSync_Cancelled(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+	dir := t.TempDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := newSyncer(server.URL, dir, "pokemon").Sync(ctx)
+	if !errors.Is(err, context.Canceled) || !strings.Contains(err.Error(), "resume") {
+		t.Errorf("expected an interrupted sync error, got %v", err)
+	}
+}
+
+func TestSync_OtherBaseURL(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+	dir := t.TempDir()
+
+	if err := newSyncer(server.URL, dir, "type").Sync(context.Background()); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	err := newSyncer("https://pokeapi.co/api/v2", dir, "type").Sync(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "holds a mirror of "+server.URL) {
+		t.Errorf("expected a base URL mismatch error, got %v", err)
+	}
+}
+
+func TestSource(t *testing.T) {
+	server := pokeapitest.New().Start()
+	dir := t.TempDir()
+	if err := newSyncer(server.URL, dir, "pokemon", "location-area").Sync(context.Background()); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	server.Close()
+
+	source, err := NewSource(dir, nil)
+	if err != nil {
+		t.Fatalf("NewSource failed: %v", err)
+	}
+	client := pokeapi.NewClient(5*time.Second, time.Minute)
+	client.SetBaseURL(source.BaseURL())
+	client.SetTransport(source)
+
+	pikachu, err := client.GetPokemonDetails("pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemonDetails failed: %v", err)
+	}
+	if pikachu.ID != 25 {
+		t.Errorf("expected pikachu's ID to be 25, got %d", pikachu.ID)
+	}
+
+	areas, err := client.ListLocationAreas(nil)
+	if err != nil {
+		t.Fatalf("ListLocationAreas failed: %v", err)
+	}
+	if areas.Count != 4 || len(areas.Results) != 4 || areas.Results[0].Name != "mt-moon-1f" {
+		t.Errorf("unexpected location area list %+v", areas)
+	}
+
+	_, err = client.GetPokemonDetails("missingno")
+	if err == nil || !strings.Contains(err.Error(), "pokemon 'missingno' not found") {
+		t.Errorf("expected a not-found error, got %v", err)
+	}
+
+	_, err = client.GetType("fire")
+	if err == nil || !strings.Contains(err.Error(), "is not in "+dir) {
+		t.Errorf("expected an error for a kind that was never synced, got %v", err)
+	}
+}
+
+func TestNewSource_Missing(t *testing.T) {
+	_, err := NewSource(t.TempDir(), nil)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package mirror
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLimit is the page size of list endpoints when none is requested,
+// matching PokeAPI.
+const DefaultLimit = 20
+
+// Source is an http.RoundTripper that answers PokeAPI requests from a data
+// directory. Requests for other hosts or kinds that were never synced go
+// to Next, as do resources missing from a kind whose sync is incomplete.
+// With a nil Next those requests fail instead.
+type Source struct {
+	Dir  string
+	Next http.RoundTripper
+
+	manifest Manifest
+
+	mu      sync.Mutex
+	indexes map[string][]entry
+}
+
+// NewSource opens the data directory dir. The error wraps os.ErrNotExist
+// when dir holds no mirror.
+func NewSource(dir string, next http.RoundTripper) (*Source, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Source{Dir: dir, Next: next, manifest: manifest, indexes: map[string][]entry{}}, nil
+}
+
+// BaseURL is the PokeAPI deployment the mirror was synced from.
+func (s *Source) BaseURL() string {
+	return s.manifest.BaseURL
+}
+
+func (s *Source) RoundTrip(req *http.Request) (*http.Response, error) {
+	rel, ok := strings.CutPrefix(req.URL.Scheme+"://"+req.URL.Host+req.URL.Path, s.manifest.BaseURL+"/")
+	kind, nameOrID, _ := strings.Cut(strings.TrimSuffix(rel, "/"), "/")
+	status, synced := s.manifest.Resources[kind]
+	if !ok || !synced || req.Method != http.MethodGet || strings.Contains(nameOrID, "/") {
+		return s.next(req)
+	}
+
+	index, err := s.index(kind)
+	if err != nil {
+		return nil, fmt.Errorf("mirror: %w", err)
+	}
+	if nameOrID == "" {
+		return s.list(req, kind, index)
+	}
+
+	for _, e := range index {
+		if e.Name != nameOrID && (e.ID == 0 || strconv.Itoa(e.ID) != nameOrID) {
+			continue
+		}
+		body, err := os.ReadFile(resourcePath(s.Dir, kind, e.Name))
+		if errors.Is(err, os.ErrNotExist) && !status.Complete {
+			return s.next(req)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("mirror: %w", err)
+		}
+		return response(req, http.StatusOK, body), nil
+	}
+	return response(req, http.StatusNotFound, []byte("Not Found")), nil
+}
+
+func (s *Source) next(req *http.Request) (*http.Response, error) {
+	if s.Next == nil {
+		return nil, fmt.Errorf("mirror: %s is not in %s", req.URL, s.Dir)
+	}
+	return s.Next.RoundTrip(req)
+}
+
+// index loads a kind's index once and keeps it for later requests.
+func (s *Source) index(kind string) ([]entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if index, ok := s.indexes[kind]; ok {
+		return index, nil
+	}
+	index, err := readIndex(s.Dir, kind)
+	if err != nil {
+		return nil, err
+	}
+	s.indexes[kind] = index
+	return index, nil
+}
+
+// list serves one page of a kind's list endpoint from its index.
+func (s *Source) list(req *http.Request, kind string, index []entry) (*http.Response, error) {
+	offset, limit := 0, DefaultLimit
+	query := req.URL.Query()
+	if n, err := strconv.Atoi(query.Get("offset")); err == nil && n > 0 {
+		offset = n
+	}
+	if n, err := strconv.Atoi(query.Get("limit")); err == nil && n > 0 {
+		limit = n
+	}
+
+	listURL := s.manifest.BaseURL + "/" + kind
+	pageURL := func(offset int) *string {
+		u := fmt.Sprintf("%s?offset=%d&limit=%d", listURL, offset, limit)
+		return &u
+	}
+
+	type namedResource struct {
+		Name string `json:"name,omitempty"`
+		URL  string `json:"url"`
+	}
+	page := struct {
+		Count    int             `json:"count"`
+		Next     *string         `json:"next"`
+		Previous *string         `json:"previous"`
+		Results  []namedResource `json:"results"`
+	}{Count: len(index), Results: []namedResource{}}
+
+	if offset+limit < len(index) {
+		page.Next = pageURL(offset + limit)
+	}
+	if offset > 0 {
+		page.Previous = pageURL(max(0, offset-limit))
+	}
+	for _, e := range index[min(offset, len(index)):min(offset+limit, len(index))] {
+		ref := e.Name
+		if e.ID != 0 {
+			ref = strconv.Itoa(e.ID)
+		}
+		result := namedResource{Name: e.Name, URL: listURL + "/" + ref + "/"}
+		if e.Unnamed {
+			result.Name = ""
+		}
+		page.Results = append(page.Results, result)
+	}
+
+	body, err := json.Marshal(page)
+	if err != nil {
+		return nil, fmt.Errorf("mirror: %w", err)
+	}
+	return response(req, http.StatusOK, body), nil
+}
+
+func response(req *http.Request, status int, body []byte) *http.Response {
+	header := http.Header{}
+	if status == http.StatusOK {
+		header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,311 @@
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+)
+
+// maxAttempts is how many times a request is tried before the resource is
+// reported as failed.
+const maxAttempts = 4
+
+// maxReportedFailures caps how many failures are spelled out in an error.
+const maxReportedFailures = 10
+
+// Syncer downloads every resource of the listed kinds from BaseURL into
+// the data directory Dir. Resources already on disk are skipped, so an
+// interrupted sync picks up where it stopped.
+type Syncer struct {
+	BaseURL   string
+	Dir       string
+	Resources []string
+	// Workers is how many downloads run at once.
+	Workers int
+	// Rate caps requests per second across all workers; zero means no cap.
+	Rate float64
+	// Backoff is the first delay before retrying a failed request. It
+	// doubles on every attempt unless the server sends Retry-After.
+	Backoff time.Duration
+	Client  *http.Client
+	// Log receives one line per resource kind as it starts and finishes.
+	Log io.Writer
+
+	limiter <-chan time.Time
+}
+
+type job struct {
+	kind string
+	name string
+	url  string
+}
+
+// Sync runs the download. When it stops early, because ctx was cancelled
+// or some resources failed, everything fetched so far is kept and the
+// manifest shows which kinds are still incomplete.
+func (s *Syncer) Sync(ctx context.Context) error {
+	baseURL := strings.TrimSuffix(s.BaseURL, "/")
+	manifest, err := ReadManifest(s.Dir)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		manifest = Manifest{Format: FormatVersion, BaseURL: baseURL, Resources: map[string]ResourceStatus{}}
+	case err != nil:
+		return err
+	case manifest.BaseURL != baseURL:
+		return fmt.Errorf("%s holds a mirror of %s, not %s", s.Dir, manifest.BaseURL, baseURL)
+	}
+
+	if s.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / s.Rate))
+		defer ticker.Stop()
+		s.limiter = ticker.C
+	}
+
+	var failures []string
+	for _, kind := range s.Resources {
+		index, err := s.fetchIndex(ctx, baseURL, kind)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			failures = append(failures, err.Error())
+			continue
+		}
+		if err := writeIndex(s.Dir, kind, index); err != nil {
+			return err
+		}
+
+		var jobs []job
+		for _, e := range index {
+			if _, err := os.Stat(resourcePath(s.Dir, kind, e.Name)); err == nil {
+				continue
+			}
+			ref := e.Name
+			if e.ID != 0 {
+				ref = strconv.Itoa(e.ID)
+			}
+			jobs = append(jobs, job{kind: kind, name: e.Name, url: baseURL + "/" + kind + "/" + ref})
+		}
+		s.logf("%s: %d resources, %d to download\n", kind, len(index), len(jobs))
+
+		kindFailures := s.download(ctx, jobs)
+		failures = append(failures, kindFailures...)
+		s.logf("%s: downloaded %d of %d\n", kind, len(jobs)-len(kindFailures), len(jobs))
+
+		status := ResourceStatus{Count: len(index)}
+		if len(kindFailures) == 0 && ctx.Err() == nil {
+			status.Complete = true
+			status.SyncedAt = time.Now().UTC()
+		}
+		manifest.Resources[kind] = status
+		manifest.UpdatedAt = time.Now().UTC()
+		if err := writeManifest(s.Dir, manifest); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("sync interrupted, run it again to resume: %w", err)
+	}
+	if len(failures) > 0 {
+		shown := failures[:min(len(failures), maxReportedFailures)]
+		msg := fmt.Sprintf("%d downloads failed, run sync again to retry them:\n  %s", len(failures), strings.Join(shown, "\n  "))
+		if len(failures) > len(shown) {
+			msg += fmt.Sprintf("\n  ...and %d more", len(failures)-len(shown))
+		}
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// fetchIndex walks a kind's list endpoint, following next links, and
+// returns every resource it names.
+func (s *Syncer) fetchIndex(ctx context.Context, baseURL, kind string) ([]entry, error) {
+	index := []entry{}
+	next := baseURL + "/" + kind + "?limit=1000"
+	for next != "" {
+		body, err := s.fetch(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+
+		var page pokeapi.NamedAPIResourceList
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("error unmarshalling JSON for %s: %w", next, err)
+		}
+		for _, result := range page.Results {
+			e := entry{Name: result.Name}
+			if e.Name == "" {
+				e.Name, e.Unnamed = pokeapi.ResourceID(result.URL), true
+			}
+			if e.Name == "" || strings.ContainsAny(e.Name, `/\`) || strings.HasPrefix(e.Name, ".") {
+				return nil, fmt.Errorf("%s lists an unusable %s name '%s'", next, kind, e.Name)
+			}
+			e.ID, _ = strconv.Atoi(pokeapi.ResourceID(result.URL))
+			index = append(index, e)
+		}
+
+		next = ""
+		if page.Next != nil {
+			next = *page.Next
+		}
+	}
+	return index, nil
+}
+
+// download runs jobs on a pool of workers and returns a line for every
+// job that failed.
+func (s *Syncer) download(ctx context.Context, jobs []job) []string {
+	queue := make(chan job)
+	var (
+		mu       sync.Mutex
+		failures []string
+		wg       sync.WaitGroup
+	)
+
+	for range max(s.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range queue {
+				if err := s.downloadOne(ctx, j); err != nil && ctx.Err() == nil {
+					mu.Lock()
+					failures = append(failures, err.Error())
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, j := range jobs {
+		select {
+		case queue <- j:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return failures
+}
+
+func (s *Syncer) downloadOne(ctx context.Context, j job) error {
+	body, err := s.fetch(ctx, j.url)
+	if err != nil {
+		return err
+	}
+	if !json.Valid(body) {
+		return fmt.Errorf("%s did not return valid JSON", j.url)
+	}
+	if err := writeFile(resourcePath(s.Dir, j.kind, j.name), body); err != nil {
+		return fmt.Errorf("error saving %s %s: %w", j.kind, j.name, err)
+	}
+	return nil
+}
+
+// fetch GETs url, retrying rate-limited, failed and unreachable requests
+// with exponential backoff.
+func (s *Syncer) fetch(ctx context.Context, url string) ([]byte, error) {
+	delay := s.Backoff
+	if delay <= 0 {
+		delay = time.Second
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err := s.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		body, retryAfter, err := s.get(ctx, url)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+		if retryAfter < 0 || attempt == maxAttempts {
+			break
+		}
+
+		pause := delay
+		if retryAfter > 0 {
+			pause = retryAfter
+		}
+		select {
+		case <-time.After(pause):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		delay *= 2
+	}
+	return nil, lastErr
+}
+
+// get makes a single request. A negative retryAfter marks an error that
+// retrying cannot fix, such as a 404.
+func (s *Syncer) get(ctx context.Context, url string) (body []byte, retryAfter time.Duration, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, -1, fmt.Errorf("error creating HTTP request for %s: %w", url, err)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error making HTTP request to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		return nil, retryAfter, fmt.Errorf("API request to %s failed with status code: %d", url, resp.StatusCode)
+	case resp.StatusCode > 299:
+		return nil, -1, fmt.Errorf("API request to %s failed with status code: %d", url, resp.StatusCode)
+	}
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading response body from %s: %w", url, err)
+	}
+	return body, 0, nil
+}
+
+// wait blocks until the rate limiter lets another request through.
+func (s *Syncer) wait(ctx context.Context) error {
+	if s.limiter == nil {
+		return ctx.Err()
+	}
+	select {
+	case <-s.limiter:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Syncer) logf(format string, args ...any) {
+	if s.Log != nil {
+		fmt.Fprintf(s.Log, format, args...)
+	}
+}
@@ -0,0 +1,29 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("JSON")
+	if err != nil || format != JSON {
+		t.Errorf("expected JSON format, got %q (%v)", format, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var b strings.Builder
+	err := WriteTable(&b, []string{"name", "base"}, [][]string{{"hp", "35"}, {"special-attack", "50"}})
+	if err != nil {
+		t.Fatalf("WriteTable failed: %v", err)
+	}
+
+	expected := "NAME            BASE\nhp              35\nspecial-attack  50\n"
+	if b.String() != expected {
+		t.Errorf("unexpected table output:\n%q\nexpected:\n%q", b.String(), expected)
+	}
+}
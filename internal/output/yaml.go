@@ -0,0 +1,186 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type yamlField struct {
+	key   string
+	value any
+}
+
+// yamlMap keeps object keys in the order encoding/json produced them so
+// YAML output lists fields in struct declaration order.
+type yamlMap []yamlField
+
+// WriteYAML renders v as block-style YAML. The value is first encoded with
+// encoding/json, so json struct tags control field names and omission.
+func WriteYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	node, err := decodeOrdered(decoder)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	switch node.(type) {
+	case yamlMap, []any:
+		lines = yamlLines(node, 0)
+	default:
+		lines = []string{yamlScalar(node)}
+	}
+
+	_, err = io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+func decodeOrdered(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, isDelim := token.(json.Delim)
+	if !isDelim {
+		return token, nil
+	}
+
+	switch delim {
+	case '{':
+		fields := yamlMap{}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, yamlField{key: keyToken.(string), value: value})
+		}
+		_, err = decoder.Token()
+		return fields, err
+	case '[':
+		items := []any{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		_, err = decoder.Token()
+		return items, err
+	}
+
+	return nil, fmt.Errorf("unexpected JSON delimiter %v", delim)
+}
+
+func yamlLines(node any, indent int) []string {
+	pad := strings.Repeat(" ", indent)
+	lines := []string{}
+
+	switch n := node.(type) {
+	case yamlMap:
+		for _, field := range n {
+			key := yamlScalar(field.key)
+			if isEmptyCollection(field.value) || !isCollection(field.value) {
+				lines = append(lines, fmt.Sprintf("%s%s: %s", pad, key, yamlScalar(field.value)))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s%s:", pad, key))
+			lines = append(lines, yamlLines(field.value, indent+2)...)
+		}
+	case []any:
+		for _, item := range n {
+			if isEmptyCollection(item) || !isCollection(item) {
+				lines = append(lines, fmt.Sprintf("%s- %s", pad, yamlScalar(item)))
+				continue
+			}
+			nested := yamlLines(item, indent+2)
+			nested[0] = pad + "- " + nested[0][indent+2:]
+			lines = append(lines, nested...)
+		}
+	}
+
+	return lines
+}
+
+func isCollection(node any) bool {
+	switch node.(type) {
+	case yamlMap, []any:
+		return true
+	}
+	return false
+}
+
+func isEmptyCollection(node any) bool {
+	switch n := node.(type) {
+	case yamlMap:
+		return len(n) == 0
+	case []any:
+		return len(n) == 0
+	}
+	return false
+}
+
+func yamlScalar(node any) string {
+	switch n := node.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(n)
+	case json.Number:
+		return n.String()
+	case string:
+		if needsQuoting(n) {
+			return strconv.Quote(n)
+		}
+		return n
+	case yamlMap:
+		return "{}"
+	case []any:
+		return "[]"
+	}
+	return fmt.Sprint(node)
+}
+
+func needsQuoting(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) {
+		return true
+	}
+
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return true
+	}
+	for _, r := range s {
+		if r < ' ' || r == 0x7f {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteYAML(t *testing.T) {
+	type stat struct {
+		Name string `json:"name"`
+		Base int    `json:"base"`
+	}
+	value := struct {
+		Name    string   `json:"name"`
+		Caught  bool     `json:"caught"`
+		Next    *string  `json:"next"`
+		Types   []string `json:"types"`
+		Moves   []string `json:"moves"`
+		Stats   []stat   `json:"stats"`
+		Comment string   `json:"comment"`
+	}{
+		Name:    "mr-mime",
+		Caught:  true,
+		Types:   []string{"psychic", "fairy"},
+		Moves:   []string{},
+		Stats:   []stat{{Name: "hp", Base: 40}, {Name: "attack", Base: 45}},
+		Comment: "yes",
+	}
+
+	var b strings.Builder
+	if err := WriteYAML(&b, value); err != nil {
+		t.Fatalf("WriteYAML failed: %v", err)
+	}
+
+	expected := `name: mr-mime
+caught: true
+next: null
+types:
+  - psychic
+  - fairy
+moves: []
+stats:
+  - name: hp
+    base: 40
+  - name: attack
+    base: 45
+comment: "yes"
+`
+	if b.String() != expected {
+		t.Errorf("unexpected YAML output:\n%s\nexpected:\n%s", b.String(), expected)
+	}
+}
+
+func TestWriteYAML_NestedLists(t *testing.T) {
+	var b strings.Builder
+	if err := WriteYAML(&b, [][]int{{1, 2}, {3}}); err != nil {
+		t.Fatalf("WriteYAML failed: %v", err)
+	}
+
+	expected := "- - 1\n  - 2\n- - 3\n"
+	if b.String() != expected {
+		t.Errorf("unexpected YAML output:\n%q\nexpected:\n%q", b.String(), expected)
+	}
+}
+
+func TestNeedsQuoting(t *testing.T) {
+	cases := map[string]bool{
+		"pikachu":      false,
+		"route 1":      false,
+		"":             true,
+		"42":           true,
+		"null":         true,
+		"- dash":       true,
+		"key: value":   true,
+		" padded":      true,
+		"line\nbreak":  true,
+		"farfetch'd":   false,
+		"nidoran-f":    false,
+		"#hashtag":     true,
+		"ends-colon:":  true,
+		"type:water":   false,
+		"'quoted'":     true,
+		"ho-oh 100%":   false,
+		"@at":          true,
+		"Off":          true,
+		"unicode-é":    false,
+		"tab\tinside":  true,
+		"3.5e2":        true,
+		"version-1.0a": false,
+	}
+
+	for input, expected := range cases {
+		if got := needsQuoting(input); got != expected {
+			t.Errorf("needsQuoting(%q) = %v, expected %v", input, got, expected)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 type Client struct {
 	httpClient http.Client
 	cache      *pokecache.Cache
+	baseURL    string
 }
 
 func NewClient(httpClientTimeout, cacheReapInterval time.Duration) *Client {
@@ -20,3 +21,22 @@ func NewClient(httpClientTimeout, cacheReapInterval time.Duration) *Client {
 		cache: pokecache.NewCache(cacheReapInterval),
 	}
 }
+
+// SetBaseURL points the client at a different PokeAPI deployment, such as a
+// self-hosted instance. An empty URL restores the package-wide BaseURL.
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = baseURL
+}
+
+func (c *Client) apiURL() string {
+	if c.baseURL != "" {
+		return c.baseURL
+	}
+	return BaseURL
+}
+
+// SetTransport replaces the transport used for every request, for example
+// to record or replay HTTP exchanges. A nil transport restores the default.
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	c.httpClient.Transport = transport
+}
@@ -0,0 +1,20 @@
+package pokeapi
+
+import (
+	"fmt"
+)
+
+func (c *Client) GetEvolutionChain(chainID string) (EvolutionChain, error) {
+	if chainID == "" {
+		return EvolutionChain{}, fmt.Errorf("evolution chain ID cannot be empty")
+	}
+
+	url := fmt.Sprintf("%s/evolution-chain/%s", c.apiURL(), chainID)
+
+	var chain EvolutionChain
+	if err := c.getResource(url, "evolution chain", chainID, &chain); err != nil {
+		return EvolutionChain{}, err
+	}
+
+	return chain, nil
+}
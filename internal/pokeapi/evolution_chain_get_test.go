@@ -0,0 +1,47 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func TestGetEvolutionChain_Success(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	chain, err := client.GetEvolutionChain(ResourceID("https://pokeapi.co/api/v2/evolution-chain/10/"))
+	if err != nil {
+		t.Fatalf("GetEvolutionChain failed: %v", err)
+	}
+	if !chain.Chain.IsBaby || chain.Chain.Species.Name != "pichu" || len(chain.Chain.EvolvesTo) != 1 {
+		t.Fatalf("Unexpected chain root: %+v", chain.Chain)
+	}
+
+	pikachu := chain.Chain.EvolvesTo[0]
+	detail := pikachu.EvolutionDetails[0]
+	if detail.MinHappiness == nil || *detail.MinHappiness != 220 || detail.MinLevel != nil {
+		t.Errorf("Expected a happiness evolution without a level, got %+v", detail)
+	}
+
+	raichu := pikachu.EvolvesTo[0].EvolutionDetails[0]
+	if raichu.Trigger.Name != "use-item" || raichu.Item == nil || raichu.Item.Name != "thunder-stone" {
+		t.Errorf("Expected a thunder-stone evolution, got %+v", raichu)
+	}
+}
+
+func TestResourceID(t *testing.T) {
+	cases := map[string]string{
+		"https://pokeapi.co/api/v2/evolution-chain/10/": "10",
+		"https://pokeapi.co/api/v2/version/red":         "red",
+	}
+	for url, expected := range cases {
+		if got := ResourceID(url); got != expected {
+			t.Errorf("ResourceID(%q) = %q, expected %q", url, got, expected)
+		}
+	}
+}
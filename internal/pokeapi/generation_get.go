@@ -0,0 +1,32 @@
+package pokeapi
+
+import (
+	"fmt"
+)
+
+func (c *Client) GetGeneration(generationNameOrID string) (Generation, error) {
+	if generationNameOrID == "" {
+		return Generation{}, fmt.Errorf("generation name cannot be empty")
+	}
+
+	url := fmt.Sprintf("%s/generation/%s", c.apiURL(), generationNameOrID)
+
+	var generation Generation
+	if err := c.getResource(url, "generation", generationNameOrID, &generation); err != nil {
+		return Generation{}, err
+	}
+
+	return generation, nil
+}
+
+// ListGenerations returns every generation in a single page.
+func (c *Client) ListGenerations() ([]NamedAPIResource, error) {
+	url := fmt.Sprintf("%s/generation?limit=100", c.apiURL())
+
+	var list NamedAPIResourceList
+	if err := c.getResource(url, "resource", "generation", &list); err != nil {
+		return nil, err
+	}
+
+	return list.Results, nil
+}
@@ -0,0 +1,49 @@
+package pokeapi
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func TestGetGeneration_Success(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	generation, err := client.GetGeneration("generation-i")
+	if err != nil {
+		t.Fatalf("GetGeneration failed: %v", err)
+	}
+	hasPikachu := slices.ContainsFunc(generation.PokemonSpecies, func(species NamedAPIResource) bool {
+		return species.Name == "pikachu"
+	})
+	if generation.MainRegion.Name != "kanto" || len(generation.PokemonSpecies) != 13 || !hasPikachu {
+		t.Errorf("Unexpected generation: %+v", generation)
+	}
+
+	if _, err := client.GetGeneration("generation-x"); err == nil || !strings.Contains(err.Error(), "generation 'generation-x' not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestListGenerations(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	generations, err := client.ListGenerations()
+	if err != nil {
+		t.Fatalf("ListGenerations failed: %v", err)
+	}
+	if len(generations) != 1 || generations[0].Name != "generation-i" {
+		t.Errorf("Expected generation-i, got %+v", generations)
+	}
+}
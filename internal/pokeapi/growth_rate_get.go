@@ -0,0 +1,20 @@
+package pokeapi
+
+import (
+	"fmt"
+)
+
+func (c *Client) GetGrowthRate(growthRateNameOrID string) (GrowthRate, error) {
+	if growthRateNameOrID == "" {
+		return GrowthRate{}, fmt.Errorf("growth rate name cannot be empty")
+	}
+
+	url := fmt.Sprintf("%s/growth-rate/%s", c.apiURL(), growthRateNameOrID)
+
+	var growthRate GrowthRate
+	if err := c.getResource(url, "growth rate", growthRateNameOrID, &growthRate); err != nil {
+		return GrowthRate{}, err
+	}
+
+	return growthRate, nil
+}
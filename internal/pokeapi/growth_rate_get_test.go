@@ -0,0 +1,41 @@
+package pokeapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func TestGetGrowthRate_Success(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	growthRate, err := client.GetGrowthRate("medium")
+	if err != nil {
+		t.Fatalf("GetGrowthRate failed: %v", err)
+	}
+	if growthRate.Formula != "x^3" || len(growthRate.Levels) != 100 {
+		t.Errorf("Unexpected growth rate: %+v", growthRate)
+	}
+	if growthRate.Levels[1].Level != 2 || growthRate.Levels[1].Experience != 8 {
+		t.Errorf("Expected level 2 to need 8 experience, got %+v", growthRate.Levels[1])
+	}
+}
+
+func TestGetGrowthRate_NotFound(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	_, err := client.GetGrowthRate("glacial")
+	if err == nil || !strings.Contains(err.Error(), "growth rate 'glacial' not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package pokeapi
+
+import (
+	"fmt"
+)
+
+func (c *Client) GetItem(itemNameOrID string) (Item, error) {
+	if itemNameOrID == "" {
+		return Item{}, fmt.Errorf("item name cannot be empty")
+	}
+
+	url := fmt.Sprintf("%s/item/%s", c.apiURL(), itemNameOrID)
+
+	var item Item
+	if err := c.getResource(url, "item", itemNameOrID, &item); err != nil {
+		return Item{}, err
+	}
+
+	return item, nil
+}
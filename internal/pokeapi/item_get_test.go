@@ -0,0 +1,29 @@
+package pokeapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func TestGetItem(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	item, err := client.GetItem("great-ball")
+	if err != nil {
+		t.Fatalf("GetItem failed: %v", err)
+	}
+	if item.Cost != 600 || item.Category.Name != "standard-balls" || len(item.EffectEntries) != 1 {
+		t.Errorf("Unexpected item: %+v", item)
+	}
+
+	if _, err := client.GetItem("rare-candy-bar"); err == nil || !strings.Contains(err.Error(), "item 'rare-candy-bar' not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
@@ -12,7 +12,7 @@ func (c *Client) GetLocationAreaDetails(locationAreaNameOrID string) (LocationAr
 		return LocationAreaDetailsResponse{}, fmt.Errorf("location area name or ID cannot be empty")
 	}
 
-	url := fmt.Sprintf("%s/location-area/%s", BaseURL, locationAreaNameOrID)
+	url := fmt.Sprintf("%s/location-area/%s", c.apiURL(), locationAreaNameOrID)
 
 	var responseBody []byte
 	var areaDetails LocationAreaDetailsResponse
@@ -7,31 +7,17 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
 )
 
 func TestGetLocationAreaDetails_Success(t *testing.T) {
-	areaName := "test-area"
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		expectedPath := fmt.Sprintf("/location-area/%s", areaName)
-		if r.URL.Path != expectedPath {
-			t.Errorf("Expected to request path '%s', got '%s'", expectedPath, r.URL.Path)
-		}
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintln(w, `{
-			"name": "test-area",
-			"pokemon_encounters": [
-				{"pokemon": {"name": "pidgey"}},
-				{"pokemon": {"name": "rattata"}}
-			]
-		}`)
-	}))
+	areaName := "kanto-route-1-area"
+	server := pokeapitest.New().Start()
 	defer server.Close()
 
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
-
 	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
 	details, err := client.GetLocationAreaDetails(areaName)
 
 	if err != nil {
@@ -52,57 +38,41 @@ func TestGetLocationAreaDetails_Success(t *testing.T) {
 }
 
 func TestGetLocationAreaDetails_CacheHit(t *testing.T) {
-	areaName := "cache-test-area"
-	requestCount := 0
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		requestCount++
-		expectedPath := fmt.Sprintf("/location-area/%s", areaName)
-		if r.URL.Path != expectedPath {
-			t.Errorf("Expected to request path '%s', got '%s'", expectedPath, r.URL.Path)
-		}
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintf(w, `{"name": "%s", "pokemon_encounters": [{"pokemon": {"name": "bulbasaur"}}]}`, areaName)
-	}))
+	areaName := "kanto-route-1-area"
+	fixtures := pokeapitest.New()
+	server := fixtures.Start()
 	defer server.Close()
 
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
-
 	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
 
 	_, err := client.GetLocationAreaDetails(areaName)
 	if err != nil {
 		t.Fatalf("First call to GetLocationAreaDetails failed: %v", err)
 	}
-	if requestCount != 1 {
-		t.Errorf("Expected 1 server request after first call, got %d", requestCount)
+	if fixtures.Requests() != 1 {
+		t.Errorf("Expected 1 server request after first call, got %d", fixtures.Requests())
 	}
 
 	details, err := client.GetLocationAreaDetails(areaName)
 	if err != nil {
 		t.Fatalf("Second call to GetLocationAreaDetails failed: %v", err)
 	}
-	if requestCount != 1 {
-		t.Errorf("Expected 1 server request total (cache hit), got %d", requestCount)
+	if fixtures.Requests() != 1 {
+		t.Errorf("Expected 1 server request total (cache hit), got %d", fixtures.Requests())
 	}
-	if details.PokemonEncounters[0].Pokemon.Name != "bulbasaur" {
-		t.Errorf("Expected 'bulbasaur' from cache, got '%s'", details.PokemonEncounters[0].Pokemon.Name)
+	if details.PokemonEncounters[0].Pokemon.Name != "pidgey" {
+		t.Errorf("Expected 'pidgey' from cache, got '%s'", details.PokemonEncounters[0].Pokemon.Name)
 	}
 }
 
 func TestGetLocationAreaDetails_NotFound(t *testing.T) {
 	areaName := "nonexistent-area"
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusNotFound)
-	}))
+	server := pokeapitest.New().Start()
 	defer server.Close()
 
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
-
 	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
 	_, err := client.GetLocationAreaDetails(areaName)
 
 	if err == nil {
@@ -115,17 +85,14 @@ func TestGetLocationAreaDetails_NotFound(t *testing.T) {
 }
 
 func TestGetLocationAreaDetails_APIError(t *testing.T) {
-	areaName := "error-area"
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusInternalServerError)
-	}))
+	areaName := "kanto-route-1-area"
+	fixtures := pokeapitest.New()
+	fixtures.Fail("/location-area/"+areaName, http.StatusInternalServerError, 0)
+	server := fixtures.Start()
 	defer server.Close()
 
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
-
 	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
 	_, err := client.GetLocationAreaDetails(areaName)
 
 	if err == nil {
@@ -144,11 +111,8 @@ func TestGetLocationAreaDetails_MalformedJSON(t *testing.T) {
 	}))
 	defer server.Close()
 
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
-
 	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
 	_, err := client.GetLocationAreaDetails(areaName)
 
 	if err == nil {
@@ -8,7 +8,7 @@ import (
 )
 
 func (c *Client) ListLocationAreas(pageURL *string) (LocationAreaResponse, error) {
-	url := BaseURL + "/location-area"
+	url := c.apiURL() + "/location-area"
 	if pageURL != nil && *pageURL != "" {
 		url = *pageURL
 	}
@@ -6,6 +6,8 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
 )
 
 func TestNewClient(t *testing.T) {
@@ -22,82 +24,64 @@ func TestNewClient(t *testing.T) {
 }
 
 func TestListLocationAreas_Success(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/location-area" {
-			t.Errorf("Expected to request '/location-area', got: %s", r.URL.Path)
-		}
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintln(w, `{"count":1,"next":"https://next.url","previous":null,"results":[{"name":"test-location","url":"https://location.url"}]}`)
-	}))
+	server := pokeapitest.New().Start()
 	defer server.Close()
 
 	client := NewClient(5*time.Second, 5*time.Minute)
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
-
-	var pageURL *string
+	client.SetBaseURL(server.URL)
 
-	resp, err := client.ListLocationAreas(pageURL)
+	resp, err := client.ListLocationAreas(nil)
 	if err != nil {
 		t.Fatalf("ListLocationAreas failed: %v", err)
 	}
 
-	if resp.Count != 1 {
-		t.Errorf("expected count 1, got %d", resp.Count)
+	if resp.Count != 4 || len(resp.Results) != 4 {
+		t.Errorf("expected 4 location areas, got count %d with %d results", resp.Count, len(resp.Results))
 	}
-	if resp.Results[0].Name != "test-location" {
-		t.Errorf("expected location name 'test-location', got '%s'", resp.Results[0].Name)
+	if resp.Results[0].Name != "mt-moon-1f" {
+		t.Errorf("expected location name 'mt-moon-1f', got '%s'", resp.Results[0].Name)
 	}
-	if resp.Next == nil || *resp.Next != "https://next.url" {
-		t.Errorf("expected next URL 'https://next.url', got '%v'", resp.Next)
+	if resp.Next != nil || resp.Previous != nil {
+		t.Errorf("expected a single page, got next %v and previous %v", resp.Next, resp.Previous)
 	}
 }
 
 func TestListLocationAreas_CacheHit(t *testing.T) {
-	requestCount := 0
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		requestCount++
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintln(w, `{"count":1,"next":null,"previous":null,"results":[{"name":"cached-location","url":"https://cached.url"}]}`)
-	}))
+	fixtures := pokeapitest.New()
+	server := fixtures.Start()
 	defer server.Close()
 
 	client := NewClient(5*time.Second, 100*time.Millisecond)
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
+	client.SetBaseURL(server.URL)
 
 	_, err := client.ListLocationAreas(nil)
 	if err != nil {
 		t.Fatalf("First call to ListLocationAreas failed: %v", err)
 	}
-	if requestCount != 1 {
-		t.Errorf("expected 1 server request after first call, got %d", requestCount)
+	if fixtures.Requests() != 1 {
+		t.Errorf("expected 1 server request after first call, got %d", fixtures.Requests())
 	}
 
 	resp, err := client.ListLocationAreas(nil)
 	if err != nil {
 		t.Fatalf("Second call to ListLocationAreas failed: %v", err)
 	}
-	if requestCount != 1 {
-		t.Errorf("expected 1 server request total (cache hit), got %d", requestCount)
+	if fixtures.Requests() != 1 {
+		t.Errorf("expected 1 server request total (cache hit), got %d", fixtures.Requests())
 	}
-	if resp.Results[0].Name != "cached-location" {
-		t.Errorf("expected location name 'cached-location' from cache, got '%s'", resp.Results[0].Name)
+	if resp.Results[0].Name != "mt-moon-1f" {
+		t.Errorf("expected location name 'mt-moon-1f' from cache, got '%s'", resp.Results[0].Name)
 	}
 }
 
 func TestListLocationAreas_APIError(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusInternalServerError)
-	}))
+	fixtures := pokeapitest.New()
+	fixtures.Fail("/location-area", http.StatusInternalServerError, 0)
+	server := fixtures.Start()
 	defer server.Close()
 
 	client := NewClient(5*time.Second, 5*time.Minute)
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
+	client.SetBaseURL(server.URL)
 
 	_, err := client.ListLocationAreas(nil)
 	if err == nil {
@@ -113,9 +97,7 @@ func TestListLocationAreas_MalformedJSON(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(5*time.Second, 5*time.Minute)
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
+	client.SetBaseURL(server.URL)
 
 	_, err := client.ListLocationAreas(nil)
 	if err == nil {
@@ -124,23 +106,47 @@ func TestListLocationAreas_MalformedJSON(t *testing.T) {
 }
 
 func TestListLocationAreas_PaginationURL(t *testing.T) {
-	expectedPathOnServer := "/specific-page-url"
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
 
+	firstPage := server.URL + "/location-area?offset=0&limit=2"
+	resp, err := client.ListLocationAreas(&firstPage)
+	if err != nil {
+		t.Fatalf("ListLocationAreas with pageURL failed: %v", err)
+	}
+	if resp.Next == nil || resp.Previous != nil {
+		t.Fatalf("expected only a next page, got next %v and previous %v", resp.Next, resp.Previous)
+	}
+
+	resp, err = client.ListLocationAreas(resp.Next)
+	if err != nil {
+		t.Fatalf("ListLocationAreas with the next page failed: %v", err)
+	}
+	if len(resp.Results) != 2 || resp.Results[1].Name != "viridian-forest-area" {
+		t.Errorf("expected the second page of location areas, got %v", resp.Results)
+	}
+	if resp.Next != nil || resp.Previous == nil || *resp.Previous != firstPage {
+		t.Errorf("expected only a previous page, got next %v and previous %v", resp.Next, resp.Previous)
+	}
+}
+
+func TestListLocationAreas_ClientBaseURL(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != expectedPathOnServer {
-			t.Errorf("Expected server to receive request for path '%s', got: '%s'", expectedPathOnServer, r.URL.Path)
+		if r.URL.Path != "/api/v2/location-area" {
+			t.Errorf("Expected to request '/api/v2/location-area', got: %s", r.URL.Path)
 		}
 		w.WriteHeader(http.StatusOK)
-		fmt.Fprintln(w, `{"count":1,"next":null,"previous":null,"results":[{"name":"page-location","url":"someurl"}]}`)
+		fmt.Fprintln(w, `{"count":0,"next":null,"previous":null,"results":[]}`)
 	}))
 	defer server.Close()
 
 	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL + "/api/v2")
 
-	pageURLToTest := server.URL + expectedPathOnServer
-
-	_, err := client.ListLocationAreas(&pageURLToTest)
+	_, err := client.ListLocationAreas(nil)
 	if err != nil {
-		t.Fatalf("ListLocationAreas with pageURL failed: %v", err)
+		t.Fatalf("ListLocationAreas with a client base URL failed: %v", err)
 	}
 }
@@ -0,0 +1,20 @@
+package pokeapi
+
+import (
+	"fmt"
+)
+
+func (c *Client) GetMove(moveNameOrID string) (Move, error) {
+	if moveNameOrID == "" {
+		return Move{}, fmt.Errorf("move name cannot be empty")
+	}
+
+	url := fmt.Sprintf("%s/move/%s", c.apiURL(), moveNameOrID)
+
+	var move Move
+	if err := c.getResource(url, "move", moveNameOrID, &move); err != nil {
+		return Move{}, err
+	}
+
+	return move, nil
+}
@@ -0,0 +1,37 @@
+package pokeapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func TestGetMove(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	thunderbolt, err := client.GetMove("thunderbolt")
+	if err != nil {
+		t.Fatalf("GetMove failed: %v", err)
+	}
+	if thunderbolt.Power == nil || *thunderbolt.Power != 90 || thunderbolt.PP != 15 || thunderbolt.Type.Name != "electric" || thunderbolt.DamageClass.Name != "special" {
+		t.Errorf("Unexpected thunderbolt: %+v", thunderbolt)
+	}
+
+	growl, err := client.GetMove("growl")
+	if err != nil {
+		t.Fatalf("GetMove failed: %v", err)
+	}
+	if growl.Power != nil {
+		t.Errorf("Expected growl to have no power, got %d", *growl.Power)
+	}
+
+	if _, err := client.GetMove("splash-attack"); err == nil || !strings.Contains(err.Error(), "move 'splash-attack' not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package pokeapi
+
+import (
+	"fmt"
+)
+
+func (c *Client) GetNature(natureNameOrID string) (Nature, error) {
+	if natureNameOrID == "" {
+		return Nature{}, fmt.Errorf("nature name cannot be empty")
+	}
+
+	url := fmt.Sprintf("%s/nature/%s", c.apiURL(), natureNameOrID)
+
+	var nature Nature
+	if err := c.getResource(url, "nature", natureNameOrID, &nature); err != nil {
+		return Nature{}, err
+	}
+
+	return nature, nil
+}
+
+// ListNatures returns every nature in a single page; there are only 25.
+func (c *Client) ListNatures() ([]NamedAPIResource, error) {
+	url := fmt.Sprintf("%s/nature?limit=100", c.apiURL())
+
+	var list NamedAPIResourceList
+	if err := c.getResource(url, "resource", "nature", &list); err != nil {
+		return nil, err
+	}
+
+	return list.Results, nil
+}
@@ -0,0 +1,57 @@
+package pokeapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func TestGetNature_Success(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	adamant, err := client.GetNature("adamant")
+	if err != nil {
+		t.Fatalf("GetNature failed: %v", err)
+	}
+	if adamant.IncreasedStat == nil || adamant.IncreasedStat.Name != "attack" || adamant.DecreasedStat.Name != "special-attack" {
+		t.Errorf("Unexpected adamant nature: %+v", adamant)
+	}
+
+	hardy, err := client.GetNature("hardy")
+	if err != nil {
+		t.Fatalf("GetNature failed: %v", err)
+	}
+	if hardy.IncreasedStat != nil || hardy.DecreasedStat != nil {
+		t.Errorf("Expected hardy to be neutral, got %+v", hardy)
+	}
+
+	if _, err := client.GetNature("grumpy"); err == nil || !strings.Contains(err.Error(), "nature 'grumpy' not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestListNatures(t *testing.T) {
+	fixtures := pokeapitest.New()
+	server := fixtures.Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	natures, err := client.ListNatures()
+	if err != nil {
+		t.Fatalf("ListNatures failed: %v", err)
+	}
+	if len(natures) != 25 || natures[0].Name != "hardy" || natures[1].Name != "lonely" {
+		t.Errorf("Expected all 25 natures in ID order, got %+v", natures)
+	}
+	if fixtures.Requests() != 1 {
+		t.Errorf("Expected every nature in a single request, got %d", fixtures.Requests())
+	}
+}
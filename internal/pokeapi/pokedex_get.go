@@ -0,0 +1,33 @@
+package pokeapi
+
+import (
+	"fmt"
+)
+
+func (c *Client) GetPokedex(pokedexNameOrID string) (Pokedex, error) {
+	if pokedexNameOrID == "" {
+		return Pokedex{}, fmt.Errorf("pokedex name cannot be empty")
+	}
+
+	url := fmt.Sprintf("%s/pokedex/%s", c.apiURL(), pokedexNameOrID)
+
+	var pokedex Pokedex
+	if err := c.getResource(url, "pokedex", pokedexNameOrID, &pokedex); err != nil {
+		return Pokedex{}, err
+	}
+
+	return pokedex, nil
+}
+
+// ListPokedexes returns every Pokédex, national and regional, in a single
+// page.
+func (c *Client) ListPokedexes() ([]NamedAPIResource, error) {
+	url := fmt.Sprintf("%s/pokedex?limit=100", c.apiURL())
+
+	var list NamedAPIResourceList
+	if err := c.getResource(url, "resource", "pokedex", &list); err != nil {
+		return nil, err
+	}
+
+	return list.Results, nil
+}
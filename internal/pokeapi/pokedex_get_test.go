@@ -0,0 +1,51 @@
+package pokeapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func TestGetPokedex_Success(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	kanto, err := client.GetPokedex("kanto")
+	if err != nil {
+		t.Fatalf("GetPokedex failed: %v", err)
+	}
+	if !kanto.IsMainSeries || kanto.Region == nil || kanto.Region.Name != "kanto" {
+		t.Errorf("Unexpected kanto pokedex: %+v", kanto)
+	}
+	if len(kanto.PokemonEntries) != 13 || kanto.PokemonEntries[1].EntryNumber != 4 || kanto.PokemonEntries[1].PokemonSpecies.Name != "charmander" {
+		t.Errorf("Unexpected entries: %+v", kanto.PokemonEntries)
+	}
+
+	if _, err := client.GetPokedex("orre"); err == nil || !strings.Contains(err.Error(), "pokedex 'orre' not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if _, err := client.GetPokedex(""); err == nil {
+		t.Error("Expected an error for an empty name")
+	}
+}
+
+func TestListPokedexes(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	pokedexes, err := client.ListPokedexes()
+	if err != nil {
+		t.Fatalf("ListPokedexes failed: %v", err)
+	}
+	if len(pokedexes) != 3 || pokedexes[1].Name != "kanto" || pokedexes[2].Name != "conquest-gallery" {
+		t.Errorf("Expected national, kanto and conquest-gallery, got %+v", pokedexes)
+	}
+}
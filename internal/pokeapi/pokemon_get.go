@@ -12,7 +12,7 @@ func (c *Client) GetPokemonDetails(pokemonName string) (Pokemon, error) {
 		return Pokemon{}, fmt.Errorf("pokemon name cannot be empty")
 	}
 
-	url := fmt.Sprintf("%s/pokemon/%s", BaseURL, pokemonName)
+	url := fmt.Sprintf("%s/pokemon/%s", c.apiURL(), pokemonName)
 
 	var responseBody []byte
 	var pokemonDetails Pokemon
@@ -7,38 +7,17 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
 )
 
 func TestGetPokemonDetails_Success(t *testing.T) {
 	pokemonName := "pikachu"
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		expectedPath := fmt.Sprintf("/pokemon/%s", pokemonName)
-		if r.URL.Path != expectedPath {
-			t.Errorf("Expected to request path '%s', got '%s'", expectedPath, r.URL.Path)
-		}
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintln(w, `{
-			"id": 25,
-			"name": "pikachu",
-			"base_experience": 112,
-			"height": 4,
-			"weight": 60,
-			"stats": [
-				{"base_stat": 35, "stat": {"name": "hp"}},
-				{"base_stat": 55, "stat": {"name": "attack"}}
-			],
-			"types": [
-				{"slot": 1, "type": {"name": "electric"}}
-			]
-		}`)
-	}))
+	server := pokeapitest.New().Start()
 	defer server.Close()
 
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
-
 	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
 	details, err := client.GetPokemonDetails(pokemonName)
 
 	if err != nil {
@@ -63,45 +42,34 @@ func TestGetPokemonDetails_Success(t *testing.T) {
 	if len(details.Types) != 1 || details.Types[0].Type.Name != "electric" {
 		t.Errorf("Expected type 'electric', got %v", details.Types)
 	}
-	if len(details.Stats) != 2 || details.Stats[0].Stat.Name != "hp" || details.Stats[0].BaseStat != 35 {
+	if len(details.Stats) != 6 || details.Stats[0].Stat.Name != "hp" || details.Stats[0].BaseStat != 35 {
 		t.Errorf("Expected first stat 'hp' with base 35, got %v", details.Stats)
 	}
 }
 
 func TestGetPokemonDetails_CacheHit(t *testing.T) {
 	pokemonName := "charmander"
-	requestCount := 0
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		requestCount++
-		expectedPath := fmt.Sprintf("/pokemon/%s", pokemonName)
-		if r.URL.Path != expectedPath {
-			t.Errorf("Expected to request path '%s', got '%s'", expectedPath, r.URL.Path)
-		}
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintf(w, `{"id": 4, "name": "%s", "base_experience": 62}`, pokemonName)
-	}))
+	fixtures := pokeapitest.New()
+	server := fixtures.Start()
 	defer server.Close()
 
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
-
 	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
 
 	_, err := client.GetPokemonDetails(pokemonName)
 	if err != nil {
 		t.Fatalf("First call to GetPokemonDetails failed: %v", err)
 	}
-	if requestCount != 1 {
-		t.Errorf("Expected 1 server request after first call, got %d", requestCount)
+	if fixtures.Requests() != 1 {
+		t.Errorf("Expected 1 server request after first call, got %d", fixtures.Requests())
 	}
 
 	details, err := client.GetPokemonDetails(pokemonName)
 	if err != nil {
 		t.Fatalf("Second call to GetPokemonDetails failed: %v", err)
 	}
-	if requestCount != 1 {
-		t.Errorf("Expected 1 server request total (cache hit), got %d", requestCount)
+	if fixtures.Requests() != 1 {
+		t.Errorf("Expected 1 server request total (cache hit), got %d", fixtures.Requests())
 	}
 	if details.Name != pokemonName || details.BaseExperience != 62 {
 		t.Errorf("Expected '%s' with base_experience 62 from cache, got '%s' with %d", pokemonName, details.Name, details.BaseExperience)
@@ -110,21 +78,11 @@ func TestGetPokemonDetails_CacheHit(t *testing.T) {
 
 func TestGetPokemonDetails_NotFound(t *testing.T) {
 	pokemonName := "nonexistentpokemon"
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == fmt.Sprintf("/pokemon/%s", pokemonName) {
-			w.WriteHeader(http.StatusNotFound)
-		} else {
-			w.WriteHeader(http.StatusInternalServerError)
-			fmt.Fprintf(w, "Unexpected request to %s", r.URL.Path)
-		}
-	}))
+	server := pokeapitest.New().Start()
 	defer server.Close()
 
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
-
 	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
 	_, err := client.GetPokemonDetails(pokemonName)
 
 	if err == nil {
@@ -137,17 +95,14 @@ func TestGetPokemonDetails_NotFound(t *testing.T) {
 }
 
 func TestGetPokemonDetails_APIError(t *testing.T) {
-	pokemonName := "errorpokemon"
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusInternalServerError)
-	}))
+	pokemonName := "pikachu"
+	fixtures := pokeapitest.New()
+	fixtures.Fail("/pokemon/"+pokemonName, http.StatusInternalServerError, 0)
+	server := fixtures.Start()
 	defer server.Close()
 
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
-
 	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
 	_, err := client.GetPokemonDetails(pokemonName)
 
 	if err == nil {
@@ -176,11 +131,8 @@ func TestGetPokemonDetails_MalformedJSON(t *testing.T) {
 	}))
 	defer server.Close()
 
-	originalBaseURL := BaseURL
-	BaseURL = server.URL
-	defer func() { BaseURL = originalBaseURL }()
-
 	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
 	_, err := client.GetPokemonDetails(pokemonName)
 
 	if err == nil {
@@ -0,0 +1,55 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// getResource fetches url through the cache and decodes the JSON body into
+// target. kind and nameOrID only feed the not-found error message.
+func (c *Client) getResource(url, kind, nameOrID string, target any) error {
+	responseBody, found := c.cache.Get(url)
+	if !found {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return fmt.Errorf("error creating HTTP request for %s: %w", url, err)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("error making HTTP request to %s: %w", url, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%s '%s' not found", kind, nameOrID)
+		}
+		if resp.StatusCode > 299 {
+			return fmt.Errorf("API request to %s failed with status code: %d", url, resp.StatusCode)
+		}
+
+		responseBody, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("error reading response body from %s: %w", url, err)
+		}
+
+		c.cache.Add(url, responseBody)
+	}
+
+	if err := json.Unmarshal(responseBody, target); err != nil {
+		return fmt.Errorf("error unmarshalling JSON for %s: %w", url, err)
+	}
+
+	return nil
+}
+
+// ResourceID returns the trailing name or ID of a resource URL, such as
+// "10" for ".../evolution-chain/10/", so it can be fetched through the
+// client's own base URL.
+func ResourceID(url string) string {
+	url = strings.TrimSuffix(url, "/")
+	return url[strings.LastIndex(url, "/")+1:]
+}
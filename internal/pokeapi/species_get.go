@@ -0,0 +1,20 @@
+package pokeapi
+
+import (
+	"fmt"
+)
+
+func (c *Client) GetPokemonSpecies(speciesNameOrID string) (PokemonSpecies, error) {
+	if speciesNameOrID == "" {
+		return PokemonSpecies{}, fmt.Errorf("species name cannot be empty")
+	}
+
+	url := fmt.Sprintf("%s/pokemon-species/%s", c.apiURL(), speciesNameOrID)
+
+	var species PokemonSpecies
+	if err := c.getResource(url, "species", speciesNameOrID, &species); err != nil {
+		return PokemonSpecies{}, err
+	}
+
+	return species, nil
+}
@@ -0,0 +1,63 @@
+package pokeapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func TestGetPokemonSpecies_Success(t *testing.T) {
+	fixtures := pokeapitest.New()
+	server := fixtures.Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	species, err := client.GetPokemonSpecies("pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemonSpecies failed: %v", err)
+	}
+	if species.CaptureRate != 190 {
+		t.Errorf("Expected capture rate 190, got %d", species.CaptureRate)
+	}
+	if species.GrowthRate.Name != "medium" {
+		t.Errorf("Expected growth rate 'medium', got '%s'", species.GrowthRate.Name)
+	}
+	if species.EvolvesFromSpecies == nil || species.EvolvesFromSpecies.Name != "pichu" {
+		t.Errorf("Expected to evolve from pichu, got %v", species.EvolvesFromSpecies)
+	}
+
+	if _, err := client.GetPokemonSpecies("pikachu"); err != nil {
+		t.Fatalf("Second call to GetPokemonSpecies failed: %v", err)
+	}
+	if fixtures.Requests() != 1 {
+		t.Errorf("Expected 1 server request total (cache hit), got %d", fixtures.Requests())
+	}
+}
+
+func TestGetPokemonSpecies_NotFound(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	_, err := client.GetPokemonSpecies("missingno")
+	if err == nil {
+		t.Fatal("Expected an error for 404 Not Found, but got nil")
+	}
+	if !strings.Contains(err.Error(), "species 'missingno' not found") {
+		t.Errorf("Expected not found error, got '%s'", err.Error())
+	}
+}
+
+func TestGetPokemonSpecies_EmptyArgument(t *testing.T) {
+	client := NewClient(5*time.Second, 5*time.Minute)
+	_, err := client.GetPokemonSpecies("")
+	if err == nil || err.Error() != "species name cannot be empty" {
+		t.Errorf("Expected empty name error, got %v", err)
+	}
+}
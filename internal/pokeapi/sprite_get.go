@@ -0,0 +1,52 @@
+package pokeapi
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+func (c *Client) GetSprite(spriteURL string) (image.Image, error) {
+	if spriteURL == "" {
+		return nil, fmt.Errorf("sprite URL cannot be empty")
+	}
+
+	var responseBody []byte
+
+	cachedData, found := c.cache.Get(spriteURL)
+	if found {
+		responseBody = cachedData
+	} else {
+		req, err := http.NewRequest("GET", spriteURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating HTTP request for %s: %w", spriteURL, err)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error making HTTP request to %s: %w", spriteURL, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode > 299 {
+			return nil, fmt.Errorf("sprite request to %s failed with status code: %d", spriteURL, resp.StatusCode)
+		}
+
+		responseBody, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading response body from %s: %w", spriteURL, err)
+		}
+
+		c.cache.Add(spriteURL, responseBody)
+	}
+
+	img, err := png.Decode(bytes.NewReader(responseBody))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding PNG from %s: %w", spriteURL, err)
+	}
+
+	return img, nil
+}
@@ -0,0 +1,55 @@
+package pokeapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func TestGetSprite_Success(t *testing.T) {
+	fixtures := pokeapitest.New()
+	server := fixtures.Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	spriteURL := server.URL + "/sprites/pokemon/25.png"
+
+	img, err := client.GetSprite(spriteURL)
+	if err != nil {
+		t.Fatalf("GetSprite failed: %v", err)
+	}
+	if img.Bounds().Dx() != 8 || img.Bounds().Dy() != 8 {
+		t.Errorf("Expected an 8x8 image, got %v", img.Bounds())
+	}
+
+	if _, err := client.GetSprite(spriteURL); err != nil {
+		t.Fatalf("Second call to GetSprite failed: %v", err)
+	}
+	if fixtures.Requests() != 1 {
+		t.Errorf("Expected 1 server request total (cache hit), got %d", fixtures.Requests())
+	}
+}
+
+func TestGetSprite_NotPNG(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	_, err := client.GetSprite(server.URL + "/pokemon/pikachu")
+	if err == nil {
+		t.Fatal("Expected an error for a non-PNG response, but got nil")
+	}
+	if !strings.Contains(err.Error(), "error decoding PNG") {
+		t.Errorf("Expected decoding error, got '%s'", err.Error())
+	}
+}
+
+func TestGetSprite_EmptyArgument(t *testing.T) {
+	client := NewClient(5*time.Second, 5*time.Minute)
+	_, err := client.GetSprite("")
+	if err == nil {
+		t.Fatal("Expected an error for empty sprite URL, but got nil")
+	}
+}
@@ -0,0 +1,20 @@
+package pokeapi
+
+import (
+	"fmt"
+)
+
+func (c *Client) GetType(typeNameOrID string) (Type, error) {
+	if typeNameOrID == "" {
+		return Type{}, fmt.Errorf("type name cannot be empty")
+	}
+
+	url := fmt.Sprintf("%s/type/%s", c.apiURL(), typeNameOrID)
+
+	var pokemonType Type
+	if err := c.getResource(url, "type", typeNameOrID, &pokemonType); err != nil {
+		return Type{}, err
+	}
+
+	return pokemonType, nil
+}
@@ -0,0 +1,30 @@
+package pokeapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func TestGetType(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	electric, err := client.GetType("electric")
+	if err != nil {
+		t.Fatalf("GetType failed: %v", err)
+	}
+	relations := electric.DamageRelations
+	if len(relations.NoDamageTo) != 1 || relations.NoDamageTo[0].Name != "ground" || len(relations.DoubleDamageTo) != 2 || len(relations.HalfDamageFrom) != 3 {
+		t.Errorf("Unexpected damage relations: %+v", relations)
+	}
+
+	if _, err := client.GetType("sound"); err == nil || !strings.Contains(err.Error(), "type 'sound' not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
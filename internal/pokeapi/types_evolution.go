@@ -0,0 +1,38 @@
+package pokeapi
+
+type EvolutionChain struct {
+	ID    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+// ChainLink is one species in an evolution chain together with the
+// species it can evolve into.
+type ChainLink struct {
+	IsBaby           bool              `json:"is_baby"`
+	Species          NamedAPIResource  `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+// EvolutionDetail lists the conditions of one way to evolve into a
+// species. Unset conditions are null or zero.
+type EvolutionDetail struct {
+	Trigger               NamedAPIResource  `json:"trigger"`
+	Item                  *NamedAPIResource `json:"item"`
+	HeldItem              *NamedAPIResource `json:"held_item"`
+	KnownMove             *NamedAPIResource `json:"known_move"`
+	KnownMoveType         *NamedAPIResource `json:"known_move_type"`
+	Location              *NamedAPIResource `json:"location"`
+	PartySpecies          *NamedAPIResource `json:"party_species"`
+	PartyType             *NamedAPIResource `json:"party_type"`
+	TradeSpecies          *NamedAPIResource `json:"trade_species"`
+	Gender                *int              `json:"gender"`
+	MinLevel              *int              `json:"min_level"`
+	MinHappiness          *int              `json:"min_happiness"`
+	MinBeauty             *int              `json:"min_beauty"`
+	MinAffection          *int              `json:"min_affection"`
+	RelativePhysicalStats *int              `json:"relative_physical_stats"`
+	NeedsOverworldRain    bool              `json:"needs_overworld_rain"`
+	TimeOfDay             string            `json:"time_of_day"`
+	TurnUpsideDown        bool              `json:"turn_upside_down"`
+}
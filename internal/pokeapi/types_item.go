@@ -0,0 +1,19 @@
+package pokeapi
+
+type Item struct {
+	ID            int                `json:"id"`
+	Name          string             `json:"name"`
+	Cost          int                `json:"cost"`
+	FlingPower    *int               `json:"fling_power"`
+	Category      NamedAPIResource   `json:"category"`
+	Attributes    []NamedAPIResource `json:"attributes"`
+	EffectEntries []struct {
+		Effect      string           `json:"effect"`
+		ShortEffect string           `json:"short_effect"`
+		Language    NamedAPIResource `json:"language"`
+	} `json:"effect_entries"`
+	Names []struct {
+		Name     string           `json:"name"`
+		Language NamedAPIResource `json:"language"`
+	} `json:"names"`
+}
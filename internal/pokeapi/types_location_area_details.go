@@ -5,53 +5,31 @@ type LocationAreaDetailsResponse struct {
 	Name                 string `json:"name"`
 	GameIndex            int    `json:"game_index"`
 	EncounterMethodRates []struct {
-		EncounterMethod struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"encounter_method"`
-		VersionDetails []struct {
-			Rate    int `json:"rate"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
+		EncounterMethod NamedAPIResource `json:"encounter_method"`
+		VersionDetails  []struct {
+			Rate    int              `json:"rate"`
+			Version NamedAPIResource `json:"version"`
 		} `json:"version_details"`
 	} `json:"encounter_method_rates"`
-	Location struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"location"`
-	Names []struct {
-		Name     string `json:"name"`
-		Language struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"language"`
+	Location NamedAPIResource `json:"location"`
+	Names    []struct {
+		Name     string           `json:"name"`
+		Language NamedAPIResource `json:"language"`
 	} `json:"names"`
 	PokemonEncounters []struct {
-		Pokemon struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"pokemon"`
-		VersionDetails []struct {
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-			MaxChance        int `json:"max_chance"`
-			EncounterDetails []struct {
-				MinLevel        int `json:"min_level"`
-				MaxLevel        int `json:"max_level"`
-				ConditionValues []struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"condition_values"`
-				Chance int `json:"chance"`
-				Method struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"method"`
-			} `json:"encounter_details"`
-		} `json:"version_details"`
+		Pokemon        NamedAPIResource          `json:"pokemon"`
+		VersionDetails []EncounterVersionDetails `json:"version_details"`
 	} `json:"pokemon_encounters"`
 }
+
+type EncounterVersionDetails struct {
+	Version          NamedAPIResource `json:"version"`
+	MaxChance        int              `json:"max_chance"`
+	EncounterDetails []struct {
+		MinLevel        int                `json:"min_level"`
+		MaxLevel        int                `json:"max_level"`
+		ConditionValues []NamedAPIResource `json:"condition_values"`
+		Chance          int                `json:"chance"`
+		Method          NamedAPIResource   `json:"method"`
+	} `json:"encounter_details"`
+}
@@ -0,0 +1,21 @@
+package pokeapi
+
+type Move struct {
+	ID           int              `json:"id"`
+	Name         string           `json:"name"`
+	Accuracy     *int             `json:"accuracy"`
+	Power        *int             `json:"power"`
+	PP           int              `json:"pp"`
+	Priority     int              `json:"priority"`
+	EffectChance *int             `json:"effect_chance"`
+	Type         NamedAPIResource `json:"type"`
+	DamageClass  NamedAPIResource `json:"damage_class"`
+	Meta         *struct {
+		CritRate     int  `json:"crit_rate"`
+		Drain        int  `json:"drain"`
+		Healing      int  `json:"healing"`
+		MinHits      *int `json:"min_hits"`
+		MaxHits      *int `json:"max_hits"`
+		FlinchChance int  `json:"flinch_chance"`
+	} `json:"meta"`
+}
@@ -0,0 +1,8 @@
+package pokeapi
+
+type Nature struct {
+	ID            int               `json:"id"`
+	Name          string            `json:"name"`
+	DecreasedStat *NamedAPIResource `json:"decreased_stat"`
+	IncreasedStat *NamedAPIResource `json:"increased_stat"`
+}
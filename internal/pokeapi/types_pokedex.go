@@ -0,0 +1,19 @@
+package pokeapi
+
+type Pokedex struct {
+	ID             int               `json:"id"`
+	Name           string            `json:"name"`
+	IsMainSeries   bool              `json:"is_main_series"`
+	Region         *NamedAPIResource `json:"region"`
+	PokemonEntries []struct {
+		EntryNumber    int              `json:"entry_number"`
+		PokemonSpecies NamedAPIResource `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+}
+
+type Generation struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	MainRegion     NamedAPIResource   `json:"main_region"`
+	PokemonSpecies []NamedAPIResource `json:"pokemon_species"`
+}
@@ -0,0 +1,31 @@
+package pokeapi
+
+type PokemonSpecies struct {
+	ID                 int               `json:"id"`
+	Name               string            `json:"name"`
+	Order              int               `json:"order"`
+	CaptureRate        int               `json:"capture_rate"`
+	BaseHappiness      int               `json:"base_happiness"`
+	GenderRate         int               `json:"gender_rate"`
+	IsBaby             bool              `json:"is_baby"`
+	IsLegendary        bool              `json:"is_legendary"`
+	IsMythical         bool              `json:"is_mythical"`
+	GrowthRate         NamedAPIResource  `json:"growth_rate"`
+	Generation         NamedAPIResource  `json:"generation"`
+	EvolvesFromSpecies *NamedAPIResource `json:"evolves_from_species"`
+	EvolutionChain     struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	PokedexNumbers []struct {
+		EntryNumber int              `json:"entry_number"`
+		Pokedex     NamedAPIResource `json:"pokedex"`
+	} `json:"pokedex_numbers"`
+	Names []struct {
+		Name     string           `json:"name"`
+		Language NamedAPIResource `json:"language"`
+	} `json:"names"`
+	Varieties []struct {
+		IsDefault bool             `json:"is_default"`
+		Pokemon   NamedAPIResource `json:"pokemon"`
+	} `json:"varieties"`
+}
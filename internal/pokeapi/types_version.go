@@ -0,0 +1,7 @@
+package pokeapi
+
+type Version struct {
+	ID           int              `json:"id"`
+	Name         string           `json:"name"`
+	VersionGroup NamedAPIResource `json:"version_group"`
+}
@@ -0,0 +1,20 @@
+package pokeapi
+
+import (
+	"fmt"
+)
+
+func (c *Client) GetVersion(versionNameOrID string) (Version, error) {
+	if versionNameOrID == "" {
+		return Version{}, fmt.Errorf("version name cannot be empty")
+	}
+
+	url := fmt.Sprintf("%s/version/%s", c.apiURL(), versionNameOrID)
+
+	var version Version
+	if err := c.getResource(url, "version", versionNameOrID, &version); err != nil {
+		return Version{}, err
+	}
+
+	return version, nil
+}
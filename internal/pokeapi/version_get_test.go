@@ -0,0 +1,29 @@
+package pokeapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+func TestGetVersion(t *testing.T) {
+	server := pokeapitest.New().Start()
+	defer server.Close()
+
+	client := NewClient(5*time.Second, 5*time.Minute)
+	client.SetBaseURL(server.URL)
+
+	version, err := client.GetVersion("red")
+	if err != nil {
+		t.Fatalf("GetVersion failed: %v", err)
+	}
+	if version.VersionGroup.Name != "red-blue" {
+		t.Errorf("Expected version group 'red-blue', got '%s'", version.VersionGroup.Name)
+	}
+
+	if _, err := client.GetVersion("purple"); err == nil || !strings.Contains(err.Error(), "version 'purple' not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
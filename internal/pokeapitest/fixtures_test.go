@@ -0,0 +1,106 @@
+package pokeapitest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+// TestFixtures reads every fixture through the real client and follows
+// the references between them, so a fixture that drifts from PokeAPI's
+// shape or points at something missing is caught here.
+func TestFixtures(t *testing.T) {
+	ts := pokeapitest.New().Start()
+	defer ts.Close()
+	client := pokeapi.NewClient(5*time.Second, time.Minute)
+	client.SetBaseURL(ts.URL)
+
+	var areas []pokeapi.NamedAPIResource
+	for page := (*string)(nil); ; {
+		resp, err := client.ListLocationAreas(page)
+		if err != nil {
+			t.Fatalf("ListLocationAreas failed: %v", err)
+		}
+		areas = append(areas, resp.Results...)
+		if resp.Next == nil {
+			break
+		}
+		page = resp.Next
+	}
+
+	pokemonNames := map[string]bool{}
+	for _, area := range areas {
+		details, err := client.GetLocationAreaDetails(area.Name)
+		if err != nil {
+			t.Fatalf("GetLocationAreaDetails(%s) failed: %v", area.Name, err)
+		}
+		for _, encounter := range details.PokemonEncounters {
+			pokemonNames[encounter.Pokemon.Name] = true
+		}
+	}
+
+	national, err := client.GetPokedex("national")
+	if err != nil {
+		t.Fatalf("GetPokedex failed: %v", err)
+	}
+	for _, entry := range national.PokemonEntries {
+		pokemonNames[entry.PokemonSpecies.Name] = true
+	}
+
+	for name := range pokemonNames {
+		pokemon, err := client.GetPokemonDetails(name)
+		if err != nil {
+			t.Fatalf("GetPokemonDetails(%s) failed: %v", name, err)
+		}
+		species, err := client.GetPokemonSpecies(pokemon.Species.Name)
+		if err != nil {
+			t.Fatalf("GetPokemonSpecies(%s) failed: %v", name, err)
+		}
+		if _, err := client.GetGrowthRate(species.GrowthRate.Name); err != nil {
+			t.Errorf("GetGrowthRate(%s) failed: %v", species.GrowthRate.Name, err)
+		}
+		if _, err := client.GetEvolutionChain(pokeapi.ResourceID(species.EvolutionChain.URL)); err != nil {
+			t.Errorf("GetEvolutionChain for %s failed: %v", name, err)
+		}
+		for _, move := range pokemon.Moves {
+			if _, err := client.GetMove(move.Move.Name); err != nil {
+				t.Errorf("GetMove(%s) failed: %v", move.Move.Name, err)
+			}
+		}
+		for _, typeEntry := range pokemon.Types {
+			if _, err := client.GetType(typeEntry.Type.Name); err != nil {
+				t.Errorf("GetType(%s) failed: %v", typeEntry.Type.Name, err)
+			}
+		}
+	}
+
+	natures, err := client.ListNatures()
+	if err != nil || len(natures) != 25 {
+		t.Fatalf("expected 25 natures, got %d (%v)", len(natures), err)
+	}
+	for _, nature := range natures {
+		if _, err := client.GetNature(nature.Name); err != nil {
+			t.Errorf("GetNature(%s) failed: %v", nature.Name, err)
+		}
+	}
+
+	generations, err := client.ListGenerations()
+	if err != nil || len(generations) == 0 {
+		t.Fatalf("ListGenerations failed: %v", err)
+	}
+	if _, err := client.GetGeneration(generations[0].Name); err != nil {
+		t.Errorf("GetGeneration failed: %v", err)
+	}
+	for _, name := range []string{"red", "blue", "yellow"} {
+		if _, err := client.GetVersion(name); err != nil {
+			t.Errorf("GetVersion(%s) failed: %v", name, err)
+		}
+	}
+	for _, name := range []string{"poke-ball", "great-ball", "ultra-ball", "master-ball", "potion", "thunder-stone"} {
+		if _, err := client.GetItem(name); err != nil {
+			t.Errorf("GetItem(%s) failed: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+// Package pokeapitest serves a small, realistic slice of PokeAPI from
+// fixture files, for tests and for offline demos. Lists are paginated the
+// way PokeAPI does it, and failures, latency and rate limiting can be
+// injected to exercise a client's error handling.
+package pokeapitest
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CanonicalURL is the base URL fixture files refer to. It is rewritten to
+// the server's own address in every response.
+const CanonicalURL = "https://pokeapi.co/api/v2"
+
+// SpritesURL is the base URL of the sprite images fixture files refer to.
+// It is rewritten to the server's /sprites path, which serves the images
+// under fixtures/sprites.
+const SpritesURL = "https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites"
+
+const (
+	apiPrefix     = "/api/v2"
+	spritesPrefix = "/sprites"
+)
+
+// DefaultLimit is the page size of list endpoints when none is requested.
+const DefaultLimit = 20
+
+//go:embed fixtures
+var embedded embed.FS
+
+type resource struct {
+	id   int
+	name string
+	body []byte
+	// unnamed resources, such as evolution chains, are listed by URL
+	// alone, as PokeAPI does.
+	unnamed bool
+}
+
+// Server is an http.Handler answering PokeAPI requests such as
+// /pokemon/pikachu, /pokemon/25 and /pokemon?offset=20&limit=20 from
+// fixture files laid out as <resource>/<name>.json. Images under sprites/
+// are served as they are, such as /sprites/pokemon/25.png.
+type Server struct {
+	resources map[string][]resource
+	sprites   map[string][]byte
+
+	mu        sync.Mutex
+	failures  map[string]*failure
+	latency   time.Duration
+	rateLimit int
+	window    time.Duration
+	recent    []time.Time
+	requests  int
+	now       func() time.Time
+}
+
+type failure struct {
+	status int
+	times  int
+}
+
+// New returns a server for the fixtures bundled with this package. It
+// panics if they cannot be read, which would be a bug in the package.
+func New() *Server {
+	fixtures, err := fs.Sub(embedded, "fixtures")
+	if err != nil {
+		panic(err)
+	}
+	s, err := NewFromFS(fixtures)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// NewFromFS returns a server for the fixture files in fsys. Each resource
+// is indexed by its file name and by the "id" field of its JSON.
+func NewFromFS(fsys fs.FS) (*Server, error) {
+	s := &Server{
+		resources: make(map[string][]resource),
+		sprites:   make(map[string][]byte),
+		failures:  make(map[string]*failure),
+		now:       time.Now,
+	}
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if strings.HasPrefix(name, "sprites/") {
+			image, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return err
+			}
+			s.sprites["/"+name] = image
+			return nil
+		}
+		if path.Ext(name) != ".json" {
+			return nil
+		}
+		kind := path.Dir(name)
+		if kind == "." || strings.Contains(kind, "/") {
+			return fmt.Errorf("fixture %s must be in a directory named after its resource", name)
+		}
+
+		body, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		var header struct {
+			ID   int     `json:"id"`
+			Name *string `json:"name"`
+		}
+		if err := json.Unmarshal(body, &header); err != nil {
+			return fmt.Errorf("fixture %s: %w", name, err)
+		}
+		s.resources[kind] = append(s.resources[kind], resource{
+			id:      header.ID,
+			name:    strings.TrimSuffix(path.Base(name), ".json"),
+			body:    body,
+			unnamed: header.Name == nil,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, list := range s.resources {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].id != list[j].id {
+				return list[i].id < list[j].id
+			}
+			return list[i].name < list[j].name
+		})
+	}
+	return s, nil
+}
+
+// Start serves s on a local port. Callers must Close the returned server.
+func (s *Server) Start() *httptest.Server {
+	return httptest.NewServer(s)
+}
+
+// Fail answers requests for urlPath, such as "/pokemon/pikachu" or
+// "/location-area" for a list, with status the next times requests, or
+// every time when times is zero or less.
+func (s *Server) Fail(urlPath string, status, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[strings.TrimSuffix(urlPath, "/")] = &failure{status: status, times: times}
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// SetRateLimit allows at most requests per window and answers the rest
+// with 429 Too Many Requests and a Retry-After header. Zero requests
+// removes the limit.
+func (s *Server) SetRateLimit(requests int, window time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimit = requests
+	s.window = window
+	s.recent = nil
+}
+
+// Requests reports how many requests have reached the server, including
+// those that were failed or rate limited.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Accept both /pokemon/pikachu and /api/v2/pokemon/pikachu so the
+	// server can stand in for either form of base URL.
+	prefix := ""
+	urlPath := strings.TrimSuffix(r.URL.Path, "/")
+	if strings.HasPrefix(urlPath, apiPrefix+"/") {
+		prefix, urlPath = apiPrefix, strings.TrimPrefix(urlPath, apiPrefix)
+	}
+	latency, status, retryAfter := s.admit(urlPath)
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+	if status != 0 {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if image, ok := s.sprites[urlPath]; ok && prefix == "" {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(image)
+		return
+	}
+
+	base := "http://" + r.Host + prefix
+	kind, nameOrID, _ := strings.Cut(strings.TrimPrefix(urlPath, "/"), "/")
+	list, ok := s.resources[kind]
+	if !ok || strings.Contains(nameOrID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	if nameOrID == "" {
+		s.serveList(w, r, base, kind, list)
+		return
+	}
+
+	for _, res := range list {
+		if res.name == nameOrID || strconv.Itoa(res.id) == nameOrID {
+			body := bytes.ReplaceAll(res.body, []byte(CanonicalURL), []byte(base))
+			body = bytes.ReplaceAll(body, []byte(SpritesURL), []byte("http://"+r.Host+spritesPrefix))
+			writeJSON(w, body)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+// admit counts a request and decides whether it is delayed, failed or
+// rate limited.
+func (s *Server) admit(urlPath string) (latency time.Duration, status, retryAfter int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	if s.rateLimit > 0 {
+		now := s.now()
+		kept := s.recent[:0]
+		for _, t := range s.recent {
+			if now.Sub(t) < s.window {
+				kept = append(kept, t)
+			}
+		}
+		s.recent = kept
+		if len(s.recent) >= s.rateLimit {
+			wait := s.window - now.Sub(s.recent[0])
+			return s.latency, http.StatusTooManyRequests, max(1, int((wait+time.Second-1)/time.Second))
+		}
+		s.recent = append(s.recent, now)
+	}
+
+	if f, ok := s.failures[urlPath]; ok {
+		if f.times > 0 {
+			f.times--
+			if f.times == 0 {
+				delete(s.failures, urlPath)
+			}
+		}
+		return s.latency, f.status, 0
+	}
+	return s.latency, 0, 0
+}
+
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, base, kind string, list []resource) {
+	offset, limit := 0, DefaultLimit
+	query := r.URL.Query()
+	if value := query.Get("offset"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+		offset = n
+	}
+	if value := query.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	type namedResource struct {
+		Name string `json:"name,omitempty"`
+		URL  string `json:"url"`
+	}
+	page := struct {
+		Count    int             `json:"count"`
+		Next     *string         `json:"next"`
+		Previous *string         `json:"previous"`
+		Results  []namedResource `json:"results"`
+	}{Count: len(list), Results: []namedResource{}}
+
+	pageURL := func(offset int) *string {
+		u := fmt.Sprintf("%s/%s?offset=%d&limit=%d", base, kind, offset, limit)
+		return &u
+	}
+	if offset+limit < len(list) {
+		page.Next = pageURL(offset + limit)
+	}
+	if offset > 0 {
+		page.Previous = pageURL(max(0, offset-limit))
+	}
+	for _, res := range list[min(offset, len(list)):min(offset+limit, len(list))] {
+		ref := res.name
+		if res.id != 0 {
+			ref = strconv.Itoa(res.id)
+		}
+		result := namedResource{Name: res.name, URL: fmt.Sprintf("%s/%s/%s/", base, kind, ref)}
+		if res.unnamed {
+			result.Name = ""
+		}
+		page.Results = append(page.Results, result)
+	}
+
+	body, err := json.Marshal(page)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, body)
+}
+
+func writeJSON(w http.ResponseWriter, body []byte) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(body)
+}
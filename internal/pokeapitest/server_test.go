@@ -0,0 +1,237 @@
+package pokeapitest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+type page struct {
+	Count    int     `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+	Results  []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"results"`
+}
+
+func get(t *testing.T, url string) (*http.Response, []byte) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading %s failed: %v", url, err)
+	}
+	return resp, body
+}
+
+func TestServer_Resource(t *testing.T) {
+	ts := New().Start()
+	defer ts.Close()
+
+	for _, path := range []string{"/pokemon/pikachu", "/pokemon/25/", "/api/v2/pokemon/pikachu"} {
+		resp, body := get(t, ts.URL+path)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", path, resp.StatusCode)
+		}
+		var pokemon struct {
+			Name    string `json:"name"`
+			Species struct {
+				URL string `json:"url"`
+			} `json:"species"`
+		}
+		if err := json.Unmarshal(body, &pokemon); err != nil {
+			t.Fatal(err)
+		}
+		if pokemon.Name != "pikachu" {
+			t.Errorf("%s: expected pikachu, got %s", path, pokemon.Name)
+		}
+		if strings.Contains(string(body), CanonicalURL) || !strings.HasPrefix(pokemon.Species.URL, ts.URL+"/") {
+			t.Errorf("%s: expected URLs to point at the server, got %s", path, pokemon.Species.URL)
+		}
+	}
+
+	for _, path := range []string{"/pokemon/missingno", "/berry/cheri", "/pokemon/pikachu/extra"} {
+		if resp, _ := get(t, ts.URL+path); resp.StatusCode != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestServer_Sprite(t *testing.T) {
+	ts := New().Start()
+	defer ts.Close()
+
+	_, body := get(t, ts.URL+"/pokemon/pikachu")
+	var pokemon struct {
+		Sprites struct {
+			BackShiny string `json:"back_shiny"`
+		} `json:"sprites"`
+	}
+	if err := json.Unmarshal(body, &pokemon); err != nil {
+		t.Fatal(err)
+	}
+	if pokemon.Sprites.BackShiny != ts.URL+"/sprites/pokemon/back/shiny/25.png" {
+		t.Fatalf("expected the sprite URL to point at the server, got %s", pokemon.Sprites.BackShiny)
+	}
+
+	resp, image := get(t, pokemon.Sprites.BackShiny)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "image/png" || !strings.HasPrefix(string(image), "\x89PNG") {
+		t.Errorf("expected a PNG, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	if resp, _ := get(t, ts.URL+"/sprites/pokemon/9999.png"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing sprite, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_Pagination(t *testing.T) {
+	ts := New().Start()
+	defer ts.Close()
+
+	var names []string
+	url := ts.URL + "/pokemon?limit=5"
+	for pages := 0; url != ""; pages++ {
+		_, body := get(t, url)
+		var p page
+		if err := json.Unmarshal(body, &p); err != nil {
+			t.Fatal(err)
+		}
+		if pages > 0 && p.Previous == nil {
+			t.Errorf("expected a previous link on page %d", pages+1)
+		}
+		for _, result := range p.Results {
+			names = append(names, result.Name)
+		}
+		url = ""
+		if p.Next != nil {
+			url = *p.Next
+		}
+		if p.Count != 13 {
+			t.Errorf("expected a count of 13, got %d", p.Count)
+		}
+	}
+	if len(names) != 13 || names[0] != "bulbasaur" || names[12] != "geodude" {
+		t.Errorf("expected every Pokémon in ID order, got %v", names)
+	}
+
+	_, body := get(t, ts.URL+"/pokemon?offset=10&limit=5")
+	var last page
+	if err := json.Unmarshal(body, &last); err != nil {
+		t.Fatal(err)
+	}
+	if last.Next != nil || last.Previous == nil || *last.Previous != ts.URL+"/pokemon?offset=5&limit=5" {
+		t.Errorf("unexpected links on the last page: next %v, previous %v", last.Next, last.Previous)
+	}
+	if last.Results[0].URL != ts.URL+"/pokemon/26/" {
+		t.Errorf("expected result URLs by ID, got %s", last.Results[0].URL)
+	}
+
+	if resp, _ := get(t, ts.URL+"/pokemon?limit=zero"); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected a bad limit to be rejected, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_UnnamedList(t *testing.T) {
+	ts := New().Start()
+	defer ts.Close()
+
+	_, body := get(t, ts.URL+"/evolution-chain?limit=3")
+	if strings.Contains(string(body), `"name"`) {
+		t.Errorf("expected evolution chains to be listed without names, got %s", body)
+	}
+	var p page
+	if err := json.Unmarshal(body, &p); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Results) != 3 || p.Results[0].URL != ts.URL+"/evolution-chain/1/" {
+		t.Errorf("expected URL-only results in ID order, got %+v", p.Results)
+	}
+}
+
+func TestServer_Fail(t *testing.T) {
+	server := New()
+	ts := server.Start()
+	defer ts.Close()
+
+	server.Fail("/pokemon/pikachu", http.StatusInternalServerError, 2)
+	for i, expected := range []int{500, 500, 200} {
+		if resp, _ := get(t, ts.URL+"/pokemon/pikachu"); resp.StatusCode != expected {
+			t.Errorf("request %d: expected %d, got %d", i+1, expected, resp.StatusCode)
+		}
+	}
+	if server.Requests() != 3 {
+		t.Errorf("expected 3 requests, got %d", server.Requests())
+	}
+}
+
+func TestServer_Latency(t *testing.T) {
+	server := New()
+	ts := server.Start()
+	defer ts.Close()
+
+	server.SetLatency(200 * time.Millisecond)
+	client := http.Client{Timeout: 50 * time.Millisecond}
+	if _, err := client.Get(ts.URL + "/pokemon/pikachu"); err == nil {
+		t.Error("expected the request to time out")
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	server := New()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	server.now = func() time.Time { return now }
+	ts := server.Start()
+	defer ts.Close()
+
+	server.SetRateLimit(2, 10*time.Second)
+	for i := 0; i < 2; i++ {
+		if resp, _ := get(t, ts.URL+"/type/fire"); resp.StatusCode != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i+1, resp.StatusCode)
+		}
+	}
+
+	now = now.Add(4 * time.Second)
+	resp, _ := get(t, ts.URL+"/type/fire")
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "6" {
+		t.Errorf("expected 429 with Retry-After 6, got %d and %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+
+	now = now.Add(6 * time.Second)
+	if resp, _ := get(t, ts.URL+"/type/fire"); resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the window to have passed, got %d", resp.StatusCode)
+	}
+}
+
+func TestNewFromFS(t *testing.T) {
+	server, err := NewFromFS(fstest.MapFS{
+		"berry/cheri.json":  {Data: []byte(`{"id": 1, "name": "cheri"}`)},
+		"berry/chesto.json": {Data: []byte(`{"id": 2, "name": "chesto"}`)},
+		"README.md":         {Data: []byte("not a fixture")},
+	})
+	if err != nil {
+		t.Fatalf("NewFromFS failed: %v", err)
+	}
+	ts := server.Start()
+	defer ts.Close()
+
+	if resp, body := get(t, ts.URL+"/berry/2"); resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "chesto") {
+		t.Errorf("expected chesto, got %d %s", resp.StatusCode, body)
+	}
+
+	if _, err := NewFromFS(fstest.MapFS{"berry/bad.json": {Data: []byte(`{`)}}); err == nil {
+		t.Error("expected malformed fixtures to be rejected")
+	}
+	if _, err := NewFromFS(fstest.MapFS{"cheri.json": {Data: []byte(`{}`)}}); err == nil {
+		t.Error("expected fixtures outside a resource directory to be rejected")
+	}
+}
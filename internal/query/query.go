@@ -0,0 +1,348 @@
+// Package query parses and evaluates the filter expressions accepted by
+// the pokedex command, such as "type:water attack>80 sort:-speed limit:5".
+package query
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Record is what a query is evaluated against: one caught Pokémon with
+// the base stats and physical details of its species.
+type Record struct {
+	ID        int
+	Dex       int
+	Level     int
+	Names     []string
+	Types     []string
+	Abilities []string
+	HeightM   float64
+	WeightKg  float64
+	Stats     map[string]int
+}
+
+// NumericFields lists the fields that can be compared with <, <=, >, >=,
+// = and != and used as sort keys.
+var NumericFields = []string{
+	"id", "dex", "level", "height", "weight",
+	"hp", "attack", "defense", "special-attack", "special-defense", "speed", "total",
+}
+
+// TextFields lists the fields matched with ":" (or "=") and "!=". Several
+// values separated by commas match any of them.
+var TextFields = []string{"name", "type", "ability"}
+
+var statFields = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// Number returns the value of a numeric field. Height is in metres and
+// weight in kilograms.
+func (r Record) Number(field string) float64 {
+	switch field {
+	case "id":
+		return float64(r.ID)
+	case "dex":
+		return float64(r.Dex)
+	case "level":
+		return float64(r.Level)
+	case "height":
+		return r.HeightM
+	case "weight":
+		return r.WeightKg
+	case "total":
+		total := 0
+		for _, stat := range statFields {
+			total += r.Stats[stat]
+		}
+		return float64(total)
+	}
+	return float64(r.Stats[field])
+}
+
+func (r Record) text(field string) []string {
+	switch field {
+	case "name":
+		return r.Names
+	case "type":
+		return r.Types
+	}
+	return r.Abilities
+}
+
+// ParseError reports where in the input a query stopped making sense.
+// Pos is a byte offset into Input.
+type ParseError struct {
+	Input string
+	Pos   int
+	Msg   string
+}
+
+func (e *ParseError) Error() string {
+	column := utf8.RuneCountInString(e.Input[:e.Pos])
+	return fmt.Sprintf("invalid query at column %d: %s\n  %s\n  %s^", column+1, e.Msg, e.Input, strings.Repeat(" ", column))
+}
+
+type filter struct {
+	field   string
+	op      string
+	number  float64
+	values  []string
+	numeric bool
+}
+
+// SortKey orders results by a numeric field or by name.
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// Query is a parsed expression. Every filter must match, results are
+// ordered by the sort keys in turn and then by ID, and a Limit of zero
+// means no limit.
+type Query struct {
+	filters []filter
+	Sort    []SortKey
+	Limit   int
+}
+
+// Fields lists the numeric fields the query filters or sorts on, in the
+// order they first appear.
+func (q Query) Fields() []string {
+	var fields []string
+	add := func(field string) {
+		if slices.Contains(NumericFields, field) && !slices.Contains(fields, field) && field != "id" {
+			fields = append(fields, field)
+		}
+	}
+	for _, f := range q.filters {
+		add(f.field)
+	}
+	for _, key := range q.Sort {
+		add(key.Field)
+	}
+	return fields
+}
+
+var operators = []string{">=", "<=", "!=", ">", "<", "=", ":"}
+
+// Parse reads a whitespace separated list of terms. Each term is a filter
+// such as "type:water" or "attack>80", a sort key list such as
+// "sort:-speed,name", or "limit:N".
+func Parse(input string) (Query, error) {
+	var q Query
+	for _, term := range splitTerms(input) {
+		if err := q.parseTerm(input, term.text, term.pos); err != nil {
+			return Query{}, err
+		}
+	}
+	return q, nil
+}
+
+type term struct {
+	text string
+	pos  int
+}
+
+func splitTerms(input string) []term {
+	var terms []term
+	start := -1
+	for i, r := range input {
+		switch {
+		case r == ' ' || r == '\t':
+			if start >= 0 {
+				terms = append(terms, term{input[start:i], start})
+				start = -1
+			}
+		case start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		terms = append(terms, term{input[start:], start})
+	}
+	return terms
+}
+
+func (q *Query) parseTerm(input, text string, pos int) error {
+	fail := func(offset int, format string, args ...any) error {
+		return &ParseError{Input: input, Pos: pos + offset, Msg: fmt.Sprintf(format, args...)}
+	}
+
+	end := strings.IndexAny(text, "<>=!:")
+	if end < 0 {
+		return fail(len(text), "expected an operator after %q", text)
+	}
+	field := strings.ToLower(text[:end])
+	if field == "" {
+		return fail(0, "expected a field name")
+	}
+	op := ""
+	for _, candidate := range operators {
+		if strings.HasPrefix(text[end:], candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return fail(end, "unknown operator %q", text[end:end+1])
+	}
+	valuePos := end + len(op)
+	value := text[valuePos:]
+	if value == "" {
+		return fail(valuePos, "expected a value after %q", text[:valuePos])
+	}
+
+	switch {
+	case field == "sort":
+		if op != ":" {
+			return fail(end, "sort takes a list of fields, as in sort:-speed")
+		}
+		return q.parseSort(value, func(offset int, format string, args ...any) error {
+			return fail(valuePos+offset, format, args...)
+		})
+	case field == "limit":
+		if op != ":" && op != "=" {
+			return fail(end, "limit takes a number, as in limit:5")
+		}
+		if q.Limit != 0 {
+			return fail(0, "limit is given more than once")
+		}
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return fail(valuePos, "limit must be a positive whole number")
+		}
+		q.Limit = limit
+	case slices.Contains(NumericFields, field):
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return fail(valuePos, "%s needs a number, got %q", field, value)
+		}
+		if op == ":" {
+			op = "="
+		}
+		q.filters = append(q.filters, filter{field: field, op: op, number: number, numeric: true})
+	case slices.Contains(TextFields, field):
+		if op != ":" && op != "=" && op != "!=" {
+			return fail(end, "%s can only be matched with \":\" or \"!=\"", field)
+		}
+		values := strings.Split(strings.ToLower(value), ",")
+		offset := valuePos
+		for _, v := range values {
+			if v == "" {
+				return fail(offset, "empty value in %q", value)
+			}
+			offset += len(v) + 1
+		}
+		q.filters = append(q.filters, filter{field: field, op: op, values: values})
+	default:
+		return fail(0, "unknown field %q", field)
+	}
+	return nil
+}
+
+func (q *Query) parseSort(value string, fail func(int, string, ...any) error) error {
+	offset := 0
+	for _, key := range strings.Split(value, ",") {
+		field, descending := strings.CutPrefix(strings.ToLower(key), "-")
+		if field != "name" && !slices.Contains(NumericFields, field) {
+			return fail(offset, "cannot sort by %q", key)
+		}
+		q.Sort = append(q.Sort, SortKey{Field: field, Descending: descending})
+		offset += len(key) + 1
+	}
+	return nil
+}
+
+// Apply returns the records that match every filter, sorted and limited.
+func (q Query) Apply(records []Record) []Record {
+	matched := make([]Record, 0, len(records))
+	for _, r := range records {
+		if q.Matches(r) {
+			matched = append(matched, r)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		for _, key := range q.Sort {
+			c := compare(matched[i], matched[j], key.Field)
+			if key.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	if q.Limit > 0 && len(matched) > q.Limit {
+		matched = matched[:q.Limit]
+	}
+	return matched
+}
+
+// Matches reports whether a record passes every filter.
+func (q Query) Matches(r Record) bool {
+	for _, f := range q.filters {
+		if !f.matches(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f filter) matches(r Record) bool {
+	if f.numeric {
+		value := r.Number(f.field)
+		switch f.op {
+		case ">":
+			return value > f.number
+		case ">=":
+			return value >= f.number
+		case "<":
+			return value < f.number
+		case "<=":
+			return value <= f.number
+		case "!=":
+			return value != f.number
+		}
+		return value == f.number
+	}
+
+	found := false
+	for _, want := range f.values {
+		for _, have := range r.text(f.field) {
+			have = strings.ToLower(have)
+			// Names match on any part so "name:pika" finds pikachu.
+			if have == want || f.field == "name" && strings.Contains(have, want) {
+				found = true
+			}
+		}
+	}
+	return found == (f.op != "!=")
+}
+
+func compare(a, b Record, field string) int {
+	if field == "name" {
+		return strings.Compare(first(a.Names), first(b.Names))
+	}
+	x, y := a.Number(field), b.Number(field)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
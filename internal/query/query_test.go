@@ -0,0 +1,120 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var records = []Record{
+	{ID: 1, Dex: 7, Level: 5, Names: []string{"squirtle"}, Types: []string{"water"}, Abilities: []string{"torrent"},
+		HeightM: 0.5, WeightKg: 9, Stats: map[string]int{"hp": 44, "attack": 48, "defense": 65, "special-attack": 50, "special-defense": 64, "speed": 43}},
+	{ID: 2, Dex: 130, Level: 20, Names: []string{"Jaws", "gyarados"}, Types: []string{"water", "flying"}, Abilities: []string{"intimidate"},
+		HeightM: 6.5, WeightKg: 235, Stats: map[string]int{"hp": 95, "attack": 125, "defense": 79, "special-attack": 60, "special-defense": 100, "speed": 81}},
+	{ID: 3, Dex: 25, Level: 12, Names: []string{"pikachu"}, Types: []string{"electric"}, Abilities: []string{"static"},
+		HeightM: 0.4, WeightKg: 6, Stats: map[string]int{"hp": 35, "attack": 55, "defense": 40, "special-attack": 50, "special-defense": 50, "speed": 90}},
+	{ID: 4, Dex: 134, Level: 30, Names: []string{"vaporeon"}, Types: []string{"water"}, Abilities: []string{"water-absorb"},
+		HeightM: 1, WeightKg: 29, Stats: map[string]int{"hp": 130, "attack": 65, "defense": 60, "special-attack": 110, "special-defense": 95, "speed": 65}},
+}
+
+func ids(records []Record) []int {
+	res := make([]int, 0, len(records))
+	for _, r := range records {
+		res = append(res, r.ID)
+	}
+	return res
+}
+
+func TestApply(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []int
+	}{
+		{"", []int{1, 2, 3, 4}},
+		{"type:water attack>50", []int{2, 4}},
+		{"type:water sort:-speed limit:2", []int{2, 4}},
+		{"type!=water", []int{3}},
+		{"type:electric,flying", []int{2, 3}},
+		{"ability:STATIC", []int{3}},
+		{"name:jaw", []int{2}},
+		{"name:gyarados", []int{2}},
+		{"total>=500", []int{2, 4}},
+		{"weight<10 height<=0.4", []int{3}},
+		{"level=12", []int{3}},
+		{"dex:25", []int{3}},
+		{"sort:name", []int{2, 3, 1, 4}},
+		{"sort:-special-attack,speed", []int{4, 2, 1, 3}},
+		{"  speed>100  ", []int{}},
+	}
+
+	for _, c := range cases {
+		q, err := Parse(c.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", c.input, err)
+			continue
+		}
+		got := ids(q.Apply(records))
+		if len(got) != len(c.expected) {
+			t.Errorf("%q: expected %v, got %v", c.input, c.expected, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.expected[i] {
+				t.Errorf("%q: expected %v, got %v", c.input, c.expected, got)
+				break
+			}
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := []struct {
+		input string
+		pos   int
+		msg   string
+	}{
+		{"type:water atack>80", 11, `unknown field "atack"`},
+		{"attack>eighty", 7, `attack needs a number`},
+		{"attack", 6, `expected an operator`},
+		{"type>water", 4, `type can only be matched`},
+		{"limit:0", 6, "limit must be a positive whole number"},
+		{"limit:1 limit:2", 8, "limit is given more than once"},
+		{"sort:-speed,colour", 12, `cannot sort by "colour"`},
+		{"type:water,,fire", 11, "empty value"},
+		{"speed>", 6, "expected a value"},
+		{":water", 0, "expected a field name"},
+	}
+
+	for _, c := range cases {
+		_, err := Parse(c.input)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%q: expected a parse error, got %v", c.input, err)
+			continue
+		}
+		if parseErr.Pos != c.pos || !strings.Contains(parseErr.Msg, c.msg) {
+			t.Errorf("%q: expected %q at %d, got %q at %d", c.input, c.msg, c.pos, parseErr.Msg, parseErr.Pos)
+		}
+	}
+}
+
+func TestParseError_PointsAtProblem(t *testing.T) {
+	_, err := Parse("type:water atack>80")
+	expected := "invalid query at column 12: unknown field \"atack\"\n" +
+		"  type:water atack>80\n" +
+		"             ^"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%v", expected, err)
+	}
+}
+
+func TestQuery_Fields(t *testing.T) {
+	q, err := Parse("type:water attack>80 id>1 sort:-speed,attack")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := q.Fields()
+	if strings.Join(fields, ",") != "attack,speed" {
+		t.Errorf("expected attack and speed, got %v", fields)
+	}
+}
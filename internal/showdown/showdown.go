@@ -0,0 +1,237 @@
+// Package showdown reads and writes teams in the plain-text format used by
+// Pokémon Showdown and most other battle simulators. Names are converted
+// to and from PokeAPI resource names, so "Mr. Mime" is read as "mr-mime"
+// and "string-shot" is written as "String Shot".
+package showdown
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Set is one Pokémon of a team. Stat keys use PokeAPI stat names; stats
+// missing from IVs and EVs take the simulator defaults of 31 and 0.
+type Set struct {
+	Species  string
+	Nickname string
+	Item     string
+	Ability  string
+	Level    int
+	Nature   string
+	IVs      map[string]int
+	EVs      map[string]int
+	Moves    []string
+}
+
+const (
+	DefaultLevel = 100
+	DefaultIV    = 31
+)
+
+var statAbbreviations = []struct {
+	stat, short string
+}{
+	{"hp", "HP"},
+	{"attack", "Atk"},
+	{"defense", "Def"},
+	{"special-attack", "SpA"},
+	{"special-defense", "SpD"},
+	{"speed", "Spe"},
+}
+
+// ID converts a display name to a PokeAPI resource name.
+func ID(name string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(name) {
+		switch {
+		case r == ' ' || r == '-' || r == '_':
+			b.WriteRune('-')
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// DisplayName converts a PokeAPI resource name to title case words.
+func DisplayName(id string) string {
+	words := strings.Split(id, "-")
+	for i, word := range words {
+		if word != "" {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			words[i] = string(runes)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// Write formats sets separated by blank lines.
+func Write(w io.Writer, sets []Set) error {
+	bw := bufio.NewWriter(w)
+	for i, set := range sets {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+
+		header := DisplayName(set.Species)
+		if set.Nickname != "" {
+			header = fmt.Sprintf("%s (%s)", set.Nickname, header)
+		}
+		if set.Item != "" {
+			header += " @ " + DisplayName(set.Item)
+		}
+		fmt.Fprintln(bw, header)
+
+		if set.Ability != "" {
+			fmt.Fprintf(bw, "Ability: %s\n", DisplayName(set.Ability))
+		}
+		if set.Level != 0 && set.Level != DefaultLevel {
+			fmt.Fprintf(bw, "Level: %d\n", set.Level)
+		}
+		if evs := spread(set.EVs, 0); evs != "" {
+			fmt.Fprintf(bw, "EVs: %s\n", evs)
+		}
+		if set.Nature != "" {
+			fmt.Fprintf(bw, "%s Nature\n", DisplayName(set.Nature))
+		}
+		if ivs := spread(set.IVs, DefaultIV); ivs != "" {
+			fmt.Fprintf(bw, "IVs: %s\n", ivs)
+		}
+		for _, move := range set.Moves {
+			fmt.Fprintf(bw, "- %s\n", DisplayName(move))
+		}
+	}
+	return bw.Flush()
+}
+
+// spread lists the stats that differ from the default, as in
+// "252 Atk / 4 Def".
+func spread(stats map[string]int, fallback int) string {
+	var parts []string
+	for _, abbreviation := range statAbbreviations {
+		value, ok := stats[abbreviation.stat]
+		if ok && value != fallback {
+			parts = append(parts, fmt.Sprintf("%d %s", value, abbreviation.short))
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+// Read parses every set in r. Errors name the offending line.
+func Read(r io.Reader) ([]Set, error) {
+	var sets []Set
+	var current *Set
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		fail := func(format string, args ...any) error {
+			return fmt.Errorf("line %d: %s", lineNumber, fmt.Sprintf(format, args...))
+		}
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "==="):
+			// Blank lines end a set; "=== [gen9] Team ===" headers group
+			// teams in exported boxes.
+			current = nil
+		case current == nil:
+			set, err := parseHeader(line)
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			sets = append(sets, set)
+			current = &sets[len(sets)-1]
+		case strings.HasPrefix(line, "- "):
+			current.Moves = append(current.Moves, ID(strings.TrimPrefix(line, "- ")))
+		case strings.HasPrefix(line, "Ability:"):
+			current.Ability = ID(strings.TrimPrefix(line, "Ability:"))
+		case strings.HasPrefix(line, "Level:"):
+			level, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "Level:")))
+			if err != nil {
+				return nil, fail("invalid level %q", strings.TrimPrefix(line, "Level:"))
+			}
+			current.Level = level
+		case strings.HasPrefix(line, "EVs:"):
+			evs, err := parseSpread(strings.TrimPrefix(line, "EVs:"))
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			current.EVs = evs
+		case strings.HasPrefix(line, "IVs:"):
+			ivs, err := parseSpread(strings.TrimPrefix(line, "IVs:"))
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			current.IVs = ivs
+		case strings.HasSuffix(line, " Nature"):
+			current.Nature = ID(strings.TrimSuffix(line, " Nature"))
+		case strings.Contains(line, ":"):
+			// Shiny, Tera Type, Happiness and the like have no equivalent
+			// here.
+		default:
+			return nil, fail("unexpected line %q", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range sets {
+		if sets[i].Level == 0 {
+			sets[i].Level = DefaultLevel
+		}
+	}
+	return sets, nil
+}
+
+// parseHeader reads "Nickname (Species) (M) @ Item", where everything but
+// the species is optional.
+func parseHeader(line string) (Set, error) {
+	var set Set
+	if name, item, ok := strings.Cut(line, "@"); ok {
+		line = strings.TrimSpace(name)
+		set.Item = ID(item)
+	}
+	line = strings.TrimSuffix(strings.TrimSuffix(line, " (M)"), " (F)")
+
+	if open := strings.LastIndex(line, " ("); open >= 0 && strings.HasSuffix(line, ")") {
+		set.Nickname = strings.TrimSpace(line[:open])
+		set.Species = ID(line[open+2 : len(line)-1])
+	} else {
+		set.Species = ID(line)
+	}
+	if set.Species == "" {
+		return Set{}, fmt.Errorf("missing species in %q", line)
+	}
+	return set, nil
+}
+
+// parseSpread reads "252 Atk / 4 Def / 252 Spe".
+func parseSpread(s string) (map[string]int, error) {
+	stats := make(map[string]int)
+	for _, part := range strings.Split(s, "/") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid stat spread %q", strings.TrimSpace(s))
+		}
+		value, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid stat value %q", fields[0])
+		}
+		stat := ""
+		for _, abbreviation := range statAbbreviations {
+			if strings.EqualFold(fields[1], abbreviation.short) {
+				stat = abbreviation.stat
+			}
+		}
+		if stat == "" {
+			return nil, fmt.Errorf("unknown stat %q", fields[1])
+		}
+		stats[stat] = value
+	}
+	return stats, nil
+}
@@ -0,0 +1,95 @@
+package showdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const team = `Shell (Metapod) (F) @ Leftovers
+Ability: Shed Skin
+Level: 7
+Shiny: Yes
+EVs: 4 HP / 252 Def
+Adamant Nature
+IVs: 0 Atk
+- Harden
+- Tackle
+
+Mr. Mime
+Ability: Filter
+Bold Nature
+- Psychic
+`
+
+func TestRead(t *testing.T) {
+	sets, err := Read(strings.NewReader(team))
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+
+	expected := []Set{
+		{
+			Species: "metapod", Nickname: "Shell", Item: "leftovers", Ability: "shed-skin", Level: 7, Nature: "adamant",
+			IVs: map[string]int{"attack": 0}, EVs: map[string]int{"hp": 4, "defense": 252}, Moves: []string{"harden", "tackle"},
+		},
+		{Species: "mr-mime", Ability: "filter", Level: 100, Nature: "bold", Moves: []string{"psychic"}},
+	}
+	if !reflect.DeepEqual(sets, expected) {
+		t.Errorf("expected %+v, got %+v", expected, sets)
+	}
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	sets := []Set{
+		{
+			Species: "metapod", Nickname: "Shell", Ability: "shed-skin", Level: 7, Nature: "adamant",
+			IVs: map[string]int{"hp": 31, "attack": 0}, EVs: map[string]int{"hp": 4, "defense": 252, "speed": 0},
+			Moves: []string{"harden", "string-shot"},
+		},
+		{Species: "caterpie", Level: 100, Moves: []string{"tackle"}},
+	}
+
+	var b strings.Builder
+	if err := Write(&b, sets); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	expected := "Shell (Metapod)\n" +
+		"Ability: Shed Skin\n" +
+		"Level: 7\n" +
+		"EVs: 4 HP / 252 Def\n" +
+		"Adamant Nature\n" +
+		"IVs: 0 Atk\n" +
+		"- Harden\n" +
+		"- String Shot\n" +
+		"\n" +
+		"Caterpie\n" +
+		"- Tackle\n"
+	if b.String() != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+
+	read, err := Read(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if read[0].Species != "metapod" || read[0].Nickname != "Shell" || read[0].Ability != "shed-skin" ||
+		read[0].Nature != "adamant" || !reflect.DeepEqual(read[0].Moves, sets[0].Moves) || read[0].IVs["attack"] != 0 {
+		t.Errorf("set did not round-trip: %+v", read[0])
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	cases := map[string]string{
+		"Caterpie\nLevel: five\n":       "line 2: invalid level",
+		"Caterpie\nEVs: 252 Attack\n":   `line 2: unknown stat "Attack"`,
+		"Caterpie\nIVs: 31\n":           "line 2: invalid stat spread",
+		"Caterpie\nthis is not a set\n": "line 2: unexpected line",
+		" @ Leftovers\n":                "line 1: missing species",
+	}
+	for input, expected := range cases {
+		if _, err := Read(strings.NewReader(input)); err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Errorf("%q: expected %q, got %v", input, expected, err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package style
+
+import (
+	"strings"
+)
+
+// Palette applies the REPL's visual theme. The zero value has color
+// disabled and returns every string unchanged.
+type Palette struct {
+	Mode Mode
+}
+
+var typeColors = map[string]RGB{
+	"normal":   {0xA8, 0xA7, 0x7A},
+	"fire":     {0xEE, 0x81, 0x30},
+	"water":    {0x63, 0x90, 0xF0},
+	"electric": {0xF7, 0xD0, 0x2C},
+	"grass":    {0x7A, 0xC7, 0x4C},
+	"ice":      {0x96, 0xD9, 0xD6},
+	"fighting": {0xC2, 0x2E, 0x28},
+	"poison":   {0xA3, 0x3E, 0xA1},
+	"ground":   {0xE2, 0xBF, 0x65},
+	"flying":   {0xA9, 0x8F, 0xF3},
+	"psychic":  {0xF9, 0x55, 0x87},
+	"bug":      {0xA6, 0xB9, 0x1A},
+	"rock":     {0xB6, 0xA1, 0x36},
+	"ghost":    {0x73, 0x57, 0x97},
+	"dragon":   {0x6F, 0x35, 0xFC},
+	"dark":     {0x70, 0x57, 0x46},
+	"steel":    {0xB7, 0xB7, 0xCE},
+	"fairy":    {0xD6, 0x85, 0xAD},
+}
+
+var (
+	colorGood    = RGB{0x4C, 0xC7, 0x5A}
+	colorBad     = RGB{0xE0, 0x4F, 0x4F}
+	colorAverage = RGB{0xF0, 0xC0, 0x30}
+	colorGreat   = RGB{0x40, 0xB8, 0xE0}
+)
+
+const (
+	// maxBaseStat is the highest single base stat in the main series
+	// (Blissey's HP), used as the full width of a stat bar.
+	maxBaseStat  = 255
+	statBarWidth = 20
+)
+
+func (p Palette) Enabled() bool {
+	return p.Mode != None
+}
+
+func (p Palette) Bold(s string) string {
+	if !p.Enabled() {
+		return s
+	}
+	return "\x1b[1m" + s + reset
+}
+
+func (p Palette) Dim(s string) string {
+	if !p.Enabled() {
+		return s
+	}
+	return "\x1b[2m" + s + reset
+}
+
+func (p Palette) Header(s string) string {
+	return p.Bold(s)
+}
+
+func (p Palette) Color(c RGB, s string) string {
+	if !p.Enabled() {
+		return s
+	}
+	return Foreground(p.Mode, c) + s + reset
+}
+
+func (p Palette) Good(s string) string {
+	return p.Color(colorGood, s)
+}
+
+func (p Palette) Bad(s string) string {
+	return p.Color(colorBad, s)
+}
+
+// Type renders a type name in its canonical color. Unknown types are left
+// unstyled.
+func (p Palette) Type(typeName string) string {
+	c, ok := typeColors[typeName]
+	if !ok || !p.Enabled() {
+		return typeName
+	}
+	return "\x1b[1m" + Foreground(p.Mode, c) + typeName + reset
+}
+
+// StatBar draws a horizontal bar proportional to a base stat, colored from
+// red for weak stats to blue for exceptional ones. It returns an empty
+// string when color is disabled so plain output is unchanged.
+func (p Palette) StatBar(baseStat int) string {
+	if !p.Enabled() {
+		return ""
+	}
+
+	filled := baseStat * statBarWidth / maxBaseStat
+	if baseStat > 0 && filled == 0 {
+		filled = 1
+	}
+	filled = min(filled, statBarWidth)
+
+	c := colorBad
+	switch {
+	case baseStat >= 120:
+		c = colorGreat
+	case baseStat >= 90:
+		c = colorGood
+	case baseStat >= 60:
+		c = colorAverage
+	}
+
+	return p.Color(c, strings.Repeat("█", filled)) + p.Dim(strings.Repeat("░", statBarWidth-filled))
+}
@@ -0,0 +1,104 @@
+package style
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+type Mode int
+
+const (
+	None Mode = iota
+	ANSI256
+	TrueColor
+)
+
+type RGB struct {
+	R, G, B uint8
+}
+
+const reset = "\x1b[0m"
+
+// Detect picks the color mode for f. Color is off when disabled is set,
+// when NO_COLOR is set to any value, when TERM is "dumb", or when f is not
+// a terminal, so piped output never carries escape codes.
+func Detect(f *os.File, disabled bool) Mode {
+	isTerminal := false
+	if info, err := f.Stat(); err == nil {
+		isTerminal = info.Mode()&os.ModeCharDevice != 0
+	}
+	return detect(isTerminal, os.Getenv, disabled)
+}
+
+func detect(isTerminal bool, getenv func(string) string, disabled bool) Mode {
+	if disabled || getenv("NO_COLOR") != "" || getenv("TERM") == "dumb" || !isTerminal {
+		return None
+	}
+
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+	return ANSI256
+}
+
+// Foreground returns the escape sequence that sets the text color to c,
+// falling back to the nearest 256-color palette entry when truecolor is
+// unavailable.
+func Foreground(mode Mode, c RGB) string {
+	switch mode {
+	case TrueColor:
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c.R, c.G, c.B)
+	case ANSI256:
+		return fmt.Sprintf("\x1b[38;5;%dm", To256(c))
+	}
+	return ""
+}
+
+func Background(mode Mode, c RGB) string {
+	switch mode {
+	case TrueColor:
+		return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", c.R, c.G, c.B)
+	case ANSI256:
+		return fmt.Sprintf("\x1b[48;5;%dm", To256(c))
+	}
+	return ""
+}
+
+// To256 maps c to the closest entry in the xterm 6x6x6 color cube or its
+// 24-step grayscale ramp.
+func To256(c RGB) int {
+	cubeIndex := func(v uint8) int {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (int(v) - 35) / 40
+	}
+	cubeLevels := [6]int{0, 95, 135, 175, 215, 255}
+
+	r, g, b := cubeIndex(c.R), cubeIndex(c.G), cubeIndex(c.B)
+	cubeColor := 16 + 36*r + 6*g + b
+	cubeDistance := distance(c, cubeLevels[r], cubeLevels[g], cubeLevels[b])
+
+	average := (int(c.R) + int(c.G) + int(c.B)) / 3
+	grayIndex := 23
+	if average < 238 {
+		grayIndex = max(0, (average-3)/10)
+	}
+	grayLevel := 8 + 10*grayIndex
+	grayDistance := distance(c, grayLevel, grayLevel, grayLevel)
+
+	if grayDistance < cubeDistance {
+		return 232 + grayIndex
+	}
+	return cubeColor
+}
+
+func distance(c RGB, r, g, b int) int {
+	dr, dg, db := int(c.R)-r, int(c.G)-g, int(c.B)-b
+	return dr*dr + dg*dg + db*db
+}
@@ -0,0 +1,83 @@
+package style
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	cases := []struct {
+		name       string
+		isTerminal bool
+		vars       map[string]string
+		disabled   bool
+		expected   Mode
+	}{
+		{"terminal defaults to 256 colors", true, nil, false, ANSI256},
+		{"truecolor terminal", true, map[string]string{"COLORTERM": "truecolor"}, false, TrueColor},
+		{"piped output", false, map[string]string{"COLORTERM": "truecolor"}, false, None},
+		{"NO_COLOR set", true, map[string]string{"NO_COLOR": "1"}, false, None},
+		{"dumb terminal", true, map[string]string{"TERM": "dumb"}, false, None},
+		{"--no-color flag", true, nil, true, None},
+	}
+
+	for _, c := range cases {
+		if got := detect(c.isTerminal, env(c.vars), c.disabled); got != c.expected {
+			t.Errorf("%s: expected mode %d, got %d", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestTo256(t *testing.T) {
+	cases := []struct {
+		color    RGB
+		expected int
+	}{
+		{RGB{0, 0, 0}, 16},
+		{RGB{255, 255, 255}, 231},
+		{RGB{255, 0, 0}, 196},
+		{RGB{128, 128, 128}, 244},
+	}
+
+	for _, c := range cases {
+		if got := To256(c.color); got != c.expected {
+			t.Errorf("To256(%v) = %d, expected %d", c.color, got, c.expected)
+		}
+	}
+}
+
+func TestPaletteDisabled(t *testing.T) {
+	var p Palette
+
+	if got := p.Type("fire"); got != "fire" {
+		t.Errorf("expected unstyled type name, got %q", got)
+	}
+	if got := p.Header("Stats:"); got != "Stats:" {
+		t.Errorf("expected unstyled header, got %q", got)
+	}
+	if got := p.StatBar(100); got != "" {
+		t.Errorf("expected no stat bar without color, got %q", got)
+	}
+}
+
+func TestStatBar(t *testing.T) {
+	p := Palette{Mode: TrueColor}
+
+	bar := p.StatBar(255)
+	if strings.Count(bar, "█") != statBarWidth || strings.Contains(bar, "░") {
+		t.Errorf("expected a full bar for the maximum base stat, got %q", bar)
+	}
+
+	bar = p.StatBar(1)
+	if strings.Count(bar, "█") != 1 {
+		t.Errorf("expected at least one filled cell for a tiny stat, got %q", bar)
+	}
+
+	if !strings.Contains(p.Type("water"), "\x1b[38;2;99;144;240m") {
+		t.Errorf("expected water to use its canonical color, got %q", p.Type("water"))
+	}
+}
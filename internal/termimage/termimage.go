@@ -0,0 +1,99 @@
+package termimage
+
+import (
+	"errors"
+	"image"
+	"strings"
+
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+)
+
+const (
+	upperHalf = "▀"
+	lowerHalf = "▄"
+	reset     = "\x1b[0m"
+
+	// alphaThreshold is the minimum alpha for a pixel to be drawn; sprite
+	// edges are often anti-aliased against full transparency.
+	alphaThreshold = 0x8000
+)
+
+var ErrNoColor = errors.New("image rendering requires a color terminal")
+
+// Render draws img using Unicode half-block characters, two pixel rows per
+// terminal line. Transparent borders are trimmed and the image is scaled
+// down with nearest-neighbor sampling to at most maxWidth columns.
+func Render(img image.Image, maxWidth int, mode style.Mode) (string, error) {
+	if mode == style.None {
+		return "", ErrNoColor
+	}
+
+	bounds := opaqueBounds(img)
+	if bounds.Empty() {
+		return "", nil
+	}
+
+	scale := 1
+	if maxWidth > 0 && bounds.Dx() > maxWidth {
+		scale = (bounds.Dx() + maxWidth - 1) / maxWidth
+	}
+
+	var b strings.Builder
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 * scale {
+		for x := bounds.Min.X; x < bounds.Max.X; x += scale {
+			top, topOpaque := pixel(img, x, y)
+			bottom, bottomOpaque := pixel(img, x, y+scale)
+			if y+scale >= bounds.Max.Y {
+				bottomOpaque = false
+			}
+
+			switch {
+			case topOpaque && bottomOpaque:
+				b.WriteString(style.Foreground(mode, top) + style.Background(mode, bottom) + upperHalf + reset)
+			case topOpaque:
+				b.WriteString(style.Foreground(mode, top) + upperHalf + reset)
+			case bottomOpaque:
+				b.WriteString(style.Foreground(mode, bottom) + lowerHalf + reset)
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String(), nil
+}
+
+func pixel(img image.Image, x, y int) (style.RGB, bool) {
+	if !(image.Point{X: x, Y: y}).In(img.Bounds()) {
+		return style.RGB{}, false
+	}
+
+	r, g, b, a := img.At(x, y).RGBA()
+	if a < alphaThreshold {
+		return style.RGB{}, false
+	}
+
+	// RGBA returns alpha-premultiplied values; undo that so partially
+	// transparent edge pixels keep their hue.
+	return style.RGB{
+		R: uint8(r * 0xffff / a >> 8),
+		G: uint8(g * 0xffff / a >> 8),
+		B: uint8(b * 0xffff / a >> 8),
+	}, true
+}
+
+func opaqueBounds(img image.Image) image.Rectangle {
+	bounds := img.Bounds()
+	opaque := image.Rectangle{}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if _, ok := pixel(img, x, y); ok {
+				opaque = opaque.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	return opaque
+}
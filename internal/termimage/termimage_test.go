@@ -0,0 +1,77 @@
+package termimage
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+)
+
+func TestRender_HalfBlocks(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	red := color.NRGBA{255, 0, 0, 255}
+	blue := color.NRGBA{0, 0, 255, 255}
+
+	img.Set(1, 1, red)
+	img.Set(1, 2, blue)
+	img.Set(2, 1, red)
+
+	out, err := Render(img, 0, style.TrueColor)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	expected := "\x1b[38;2;255;0;0m\x1b[48;2;0;0;255m▀\x1b[0m" +
+		"\x1b[38;2;255;0;0m▀\x1b[0m\n"
+	if out != expected {
+		t.Errorf("unexpected rendering:\n%q\nexpected:\n%q", out, expected)
+	}
+}
+
+func TestRender_LowerHalfAndTransparency(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.NRGBA{0, 255, 0, 255})
+	img.Set(1, 1, color.NRGBA{0, 255, 0, 255})
+
+	out, err := Render(img, 0, style.ANSI256)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	expected := "\x1b[38;5;46m▀\x1b[0m\x1b[38;5;46m▄\x1b[0m\n"
+	if out != expected {
+		t.Errorf("unexpected rendering:\n%q\nexpected:\n%q", out, expected)
+	}
+}
+
+func TestRender_ScalesToMaxWidth(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 40))
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 40; x++ {
+			img.Set(x, y, color.NRGBA{200, 200, 200, 255})
+		}
+	}
+
+	out, err := Render(img, 10, style.TrueColor)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 5 {
+		t.Errorf("expected 5 lines, got %d", len(lines))
+	}
+	if cells := strings.Count(lines[0], "▀"); cells != 10 {
+		t.Errorf("expected 10 columns, got %d", cells)
+	}
+}
+
+func TestRender_NoColor(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	if _, err := Render(img, 0, style.None); !errors.Is(err, ErrNoColor) {
+		t.Errorf("expected ErrNoColor, got %v", err)
+	}
+}
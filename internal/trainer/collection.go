@@ -0,0 +1,146 @@
+package trainer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Pokemon is one individual the player has caught. Species is the PokeAPI
+// pokemon resource name used to look up its data.
+type Pokemon struct {
+	ID         int       `json:"id"`
+	Species    string    `json:"species"`
+	Nickname   string    `json:"nickname,omitempty"`
+	Level      int       `json:"level"`
+	Experience int       `json:"experience"`
+	IVs        Stats     `json:"ivs,omitempty"`
+	EVs        Stats     `json:"evs,omitempty"`
+	Nature     Nature    `json:"nature"`
+	Ability    string    `json:"ability,omitempty"`
+	Moves      []string  `json:"moves,omitempty"`
+	CaughtAt   time.Time `json:"caught_at"`
+	Location   string    `json:"location"`
+}
+
+// Name is the nickname if one was given, otherwise the species.
+func (p Pokemon) Name() string {
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+	return p.Species
+}
+
+// Collection holds every individual the player owns, in the order they
+// were caught, along with the IDs kept in the party and in each PC box.
+// IDs are never reused, even after a release.
+type Collection struct {
+	NextID  int       `json:"next_id"`
+	Pokemon []Pokemon `json:"pokemon"`
+	Party   []int     `json:"party"`
+	Boxes   [][]int   `json:"boxes"`
+}
+
+// Add assigns the next ID to p and stores it in the party, or in a box if
+// the party is full.
+func (c *Collection) Add(p Pokemon) (Pokemon, Place) {
+	if c.NextID < 1 {
+		c.NextID = 1
+	}
+	p.ID = c.NextID
+	c.NextID++
+	c.Pokemon = append(c.Pokemon, p)
+	return p, c.store(p.ID)
+}
+
+// NotCaughtError is returned by Find when a name matches no individual,
+// so callers can fall back to the species of that name.
+type NotCaughtError struct {
+	Name string
+}
+
+func (e *NotCaughtError) Error() string {
+	return fmt.Sprintf("you have not caught a Pokémon called %s", e.Name)
+}
+
+// Find resolves a reference typed by the player: an ID ("3" or "#3"), a
+// nickname, or a species name when only one of that species is owned.
+func (c *Collection) Find(ref string) (*Pokemon, error) {
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		for i := range c.Pokemon {
+			if c.Pokemon[i].ID == id {
+				return &c.Pokemon[i], nil
+			}
+		}
+		return nil, fmt.Errorf("you have no Pokémon with ID %d", id)
+	}
+
+	for i := range c.Pokemon {
+		if strings.EqualFold(c.Pokemon[i].Nickname, ref) {
+			return &c.Pokemon[i], nil
+		}
+	}
+
+	var matches []*Pokemon
+	for i := range c.Pokemon {
+		if strings.EqualFold(c.Pokemon[i].Species, ref) {
+			matches = append(matches, &c.Pokemon[i])
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, &NotCaughtError{Name: ref}
+	case 1:
+		return matches[0], nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, match := range matches {
+		ids[i] = "#" + strconv.Itoa(match.ID)
+	}
+	return nil, fmt.Errorf("you have %d %s (%s); use an ID or nickname", len(matches), ref, strings.Join(ids, ", "))
+}
+
+// Release removes an individual for good. The last party member cannot be
+// released.
+func (c *Collection) Release(id int) (Pokemon, error) {
+	for i, p := range c.Pokemon {
+		if p.ID != id {
+			continue
+		}
+		if place, ok := c.Locate(id); ok && place.InParty() && len(c.Party) == 1 {
+			return Pokemon{}, fmt.Errorf("you can't release your last party Pokémon")
+		}
+		c.unstore(id)
+		c.Pokemon = append(c.Pokemon[:i], c.Pokemon[i+1:]...)
+		return p, nil
+	}
+	return Pokemon{}, fmt.Errorf("you have no Pokémon with ID %d", id)
+}
+
+// CountSpecies returns how many individuals of a species are owned.
+func (c *Collection) CountSpecies(species string) int {
+	count := 0
+	for _, p := range c.Pokemon {
+		if p.Species == species {
+			count++
+		}
+	}
+	return count
+}
+
+// ValidateNickname checks a proposed nickname. Numeric nicknames are
+// refused because they would be indistinguishable from IDs.
+func ValidateNickname(nickname string) error {
+	if nickname == "" {
+		return fmt.Errorf("nickname cannot be empty")
+	}
+	if len([]rune(nickname)) > 12 {
+		return fmt.Errorf("nickname '%s' is longer than 12 characters", nickname)
+	}
+	if _, err := strconv.Atoi(strings.TrimPrefix(nickname, "#")); err == nil {
+		return fmt.Errorf("nickname '%s' cannot be a number", nickname)
+	}
+	return nil
+}
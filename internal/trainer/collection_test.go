@@ -0,0 +1,101 @@
+package trainer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCollectionAddAndFind(t *testing.T) {
+	var c Collection
+
+	first, _ := c.Add(Pokemon{Species: "pikachu", Level: 5})
+	second, _ := c.Add(Pokemon{Species: "pikachu", Level: 7, Nickname: "Sparky"})
+	third, _ := c.Add(Pokemon{Species: "caterpie", Level: 3})
+
+	if first.ID != 1 || second.ID != 2 || third.ID != 3 {
+		t.Fatalf("expected sequential IDs, got %d, %d, %d", first.ID, second.ID, third.ID)
+	}
+
+	cases := map[string]int{
+		"1":        1,
+		"#2":       2,
+		"sparky":   2,
+		"caterpie": 3,
+	}
+	for ref, expectedID := range cases {
+		p, err := c.Find(ref)
+		if err != nil {
+			t.Errorf("Find(%s) failed: %v", ref, err)
+			continue
+		}
+		if p.ID != expectedID {
+			t.Errorf("Find(%s) returned ID %d, expected %d", ref, p.ID, expectedID)
+		}
+	}
+
+	_, err := c.Find("pikachu")
+	if err == nil || !strings.Contains(err.Error(), "#1, #2") {
+		t.Errorf("expected ambiguous species error listing IDs, got %v", err)
+	}
+	if _, err := c.Find("9"); err == nil {
+		t.Error("expected error for unknown ID")
+	}
+	var notCaught *NotCaughtError
+	if _, err := c.Find("bulbasaur"); !errors.As(err, &notCaught) || notCaught.Name != "bulbasaur" {
+		t.Errorf("expected a NotCaughtError for species not owned, got %v", err)
+	}
+	if _, err := c.Find("9"); errors.As(err, &notCaught) {
+		t.Error("expected an unknown ID not to be reported as an uncaught name")
+	}
+}
+
+func TestCollectionRelease(t *testing.T) {
+	var c Collection
+	c.Add(Pokemon{Species: "pidgey"})
+	c.Add(Pokemon{Species: "rattata"})
+	c.Add(Pokemon{Species: "zubat"})
+
+	released, err := c.Release(1)
+	if err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if released.Species != "pidgey" || len(c.Pokemon) != 2 || len(c.Party) != 2 {
+		t.Errorf("expected pidgey to be released, got %+v with %d left", released, len(c.Pokemon))
+	}
+
+	if next, _ := c.Add(Pokemon{Species: "spearow"}); next.ID != 4 {
+		t.Errorf("expected released IDs not to be reused, got %d", next.ID)
+	}
+	if _, err := c.Release(1); err == nil {
+		t.Error("expected error releasing the same Pokémon twice")
+	}
+}
+
+func TestValidateNickname(t *testing.T) {
+	if err := ValidateNickname("Sparky"); err != nil {
+		t.Errorf("expected Sparky to be valid, got %v", err)
+	}
+	for _, bad := range []string{"", "42", "#7", "ThisNameIsTooLong"} {
+		if err := ValidateNickname(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestPokedex(t *testing.T) {
+	d := Pokedex{}
+	d.MarkSeen("rattata")
+	d.MarkCaught("pidgey")
+	d.MarkSeen("pidgey")
+
+	if !d.HasSeen("rattata") || d.HasCaught("rattata") {
+		t.Error("expected rattata to be seen but not caught")
+	}
+	if !d.HasCaught("pidgey") || !d.HasSeen("pidgey") {
+		t.Error("expected pidgey to stay caught after being seen again")
+	}
+	if d.HasSeen("mew") {
+		t.Error("expected mew to be unseen")
+	}
+}
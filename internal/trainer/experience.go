@@ -0,0 +1,78 @@
+package trainer
+
+import (
+	"fmt"
+)
+
+const MaxLevel = 100
+
+// GrowthCurve holds the total experience needed to reach each level;
+// index 0 is level 1.
+type GrowthCurve []int
+
+// NewGrowthCurve builds a curve from level/experience pairs as listed by
+// PokeAPI's growth-rate resource, which need not be sorted.
+func NewGrowthCurve(levels map[int]int) (GrowthCurve, error) {
+	curve := make(GrowthCurve, MaxLevel)
+	for level := 1; level <= MaxLevel; level++ {
+		exp, ok := levels[level]
+		if !ok {
+			return nil, fmt.Errorf("growth curve is missing level %d", level)
+		}
+		if level > 1 && exp < curve[level-2] {
+			return nil, fmt.Errorf("growth curve decreases at level %d", level)
+		}
+		curve[level-1] = exp
+	}
+	return curve, nil
+}
+
+// Experience is the total experience at which a Pokémon reaches level.
+func (c GrowthCurve) Experience(level int) int {
+	if level < 1 {
+		level = 1
+	}
+	if level > len(c) {
+		level = len(c)
+	}
+	return c[level-1]
+}
+
+// Level is the level reached with the given experience total.
+func (c GrowthCurve) Level(exp int) int {
+	level := 1
+	for level < len(c) && c[level] <= exp {
+		level++
+	}
+	return level
+}
+
+// ExperienceYield is the experience gained for defeating or catching a
+// Pokémon with the given base_experience at the given level, using the
+// scaling-free formula from Generation VI onwards.
+func ExperienceYield(baseExperience, level int) int {
+	yield := baseExperience * level / 7
+	if yield < 1 {
+		yield = 1
+	}
+	return yield
+}
+
+// GainExperience adds exp to p, capped at the maximum level, and returns
+// each level reached on the way.
+func (p *Pokemon) GainExperience(exp int, curve GrowthCurve) []int {
+	if p.Experience < curve.Experience(p.Level) {
+		p.Experience = curve.Experience(p.Level)
+	}
+	p.Experience += exp
+	if maxExp := curve.Experience(MaxLevel); p.Experience > maxExp {
+		p.Experience = maxExp
+	}
+
+	var reached []int
+	for newLevel := curve.Level(p.Experience); p.Level < newLevel; {
+		p.Level++
+		reached = append(reached, p.Level)
+	}
+	return reached
+}
@@ -0,0 +1,67 @@
+package trainer
+
+import (
+	"testing"
+)
+
+// mediumFast is the x^3 curve.
+func mediumFast(t *testing.T) GrowthCurve {
+	t.Helper()
+	levels := make(map[int]int, MaxLevel)
+	for level := 1; level <= MaxLevel; level++ {
+		levels[level] = level * level * level
+	}
+	levels[1] = 0
+
+	curve, err := NewGrowthCurve(levels)
+	if err != nil {
+		t.Fatalf("NewGrowthCurve failed: %v", err)
+	}
+	return curve
+}
+
+func TestNewGrowthCurve_Invalid(t *testing.T) {
+	if _, err := NewGrowthCurve(map[int]int{1: 0, 2: 8}); err == nil {
+		t.Error("expected a curve missing levels to be rejected")
+	}
+}
+
+func TestGrowthCurve_Level(t *testing.T) {
+	curve := mediumFast(t)
+
+	cases := map[int]int{0: 1, 7: 1, 8: 2, 124: 4, 125: 5, 1_000_000: 100, 2_000_000: 100}
+	for exp, expected := range cases {
+		if level := curve.Level(exp); level != expected {
+			t.Errorf("expected %d experience to be level %d, got %d", exp, expected, level)
+		}
+	}
+}
+
+func TestExperienceYield(t *testing.T) {
+	if yield := ExperienceYield(112, 5); yield != 80 {
+		t.Errorf("expected pikachu at level 5 to yield 80, got %d", yield)
+	}
+	if yield := ExperienceYield(0, 1); yield != 1 {
+		t.Errorf("expected a minimum yield of 1, got %d", yield)
+	}
+}
+
+func TestGainExperience(t *testing.T) {
+	curve := mediumFast(t)
+	p := Pokemon{Level: 5, Experience: 125}
+
+	reached := p.GainExperience(150, curve)
+	if p.Experience != 275 || p.Level != 6 || len(reached) != 1 || reached[0] != 6 {
+		t.Errorf("expected one level-up to 6 with 275 experience, got level %d, %d experience, %v", p.Level, p.Experience, reached)
+	}
+
+	reached = p.GainExperience(1000, curve)
+	if p.Level != 10 || len(reached) != 4 {
+		t.Errorf("expected to reach level 10 over four level-ups, got level %d, %v", p.Level, reached)
+	}
+
+	p.GainExperience(5_000_000, curve)
+	if p.Level != MaxLevel || p.Experience != 1_000_000 {
+		t.Errorf("expected experience capped at level 100, got level %d, %d experience", p.Level, p.Experience)
+	}
+}
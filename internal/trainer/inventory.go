@@ -0,0 +1,69 @@
+package trainer
+
+import (
+	"fmt"
+	"sort"
+)
+
+const StartingMoney = 3000
+
+// Inventory is the player's money and bag, keyed by PokeAPI item name.
+type Inventory struct {
+	Money int            `json:"money"`
+	Items map[string]int `json:"items"`
+}
+
+// NewInventory is what a new player starts with.
+func NewInventory() Inventory {
+	return Inventory{
+		Money: StartingMoney,
+		Items: map[string]int{"poke-ball": 5, "potion": 1},
+	}
+}
+
+func (inv *Inventory) Count(item string) int {
+	return inv.Items[item]
+}
+
+func (inv *Inventory) Add(item string, quantity int) {
+	if inv.Items == nil {
+		inv.Items = make(map[string]int)
+	}
+	inv.Items[item] += quantity
+}
+
+func (inv *Inventory) Remove(item string, quantity int) error {
+	have := inv.Items[item]
+	if have == 0 {
+		return fmt.Errorf("you don't have any %s", item)
+	}
+	if have < quantity {
+		return fmt.Errorf("you only have %d %s", have, item)
+	}
+
+	if have == quantity {
+		delete(inv.Items, item)
+	} else {
+		inv.Items[item] = have - quantity
+	}
+	return nil
+}
+
+// Spend takes money out of the wallet, refusing to go below zero.
+func (inv *Inventory) Spend(amount int) error {
+	if amount > inv.Money {
+		return fmt.Errorf("you need ₽%d but only have ₽%d", amount, inv.Money)
+	}
+	inv.Money -= amount
+	return nil
+}
+
+// Names lists the items in the bag alphabetically.
+func (inv *Inventory) Names() []string {
+	names := make([]string, 0, len(inv.Items))
+	for name := range inv.Items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,33 @@
+package trainer
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInventory(t *testing.T) {
+	inv := Inventory{Money: 1000}
+
+	inv.Add("potion", 2)
+	inv.Add("poke-ball", 3)
+	if !slices.Equal(inv.Names(), []string{"poke-ball", "potion"}) {
+		t.Errorf("expected sorted item names, got %v", inv.Names())
+	}
+
+	if err := inv.Remove("potion", 3); err == nil {
+		t.Error("expected removing more than owned to fail")
+	}
+	if err := inv.Remove("potion", 2); err != nil || inv.Count("potion") != 0 || slices.Contains(inv.Names(), "potion") {
+		t.Errorf("expected the last potions to be removed, got %v, %v", inv.Items, err)
+	}
+	if err := inv.Remove("potion", 1); err == nil {
+		t.Error("expected removing a missing item to fail")
+	}
+
+	if err := inv.Spend(1200); err == nil || inv.Money != 1000 {
+		t.Errorf("expected overspending to fail and keep the money, got %v, %d", err, inv.Money)
+	}
+	if err := inv.Spend(600); err != nil || inv.Money != 400 {
+		t.Errorf("expected ₽400 left, got %d, %v", inv.Money, err)
+	}
+}
@@ -0,0 +1,21 @@
+package trainer
+
+import (
+	"slices"
+)
+
+const MaxMoves = 4
+
+// LearnMove teaches p a move. With four moves already known the oldest is
+// forgotten to make room, and its name is returned.
+func (p *Pokemon) LearnMove(move string) (forgotten string, learned bool) {
+	if slices.Contains(p.Moves, move) {
+		return "", false
+	}
+	if len(p.Moves) >= MaxMoves {
+		forgotten = p.Moves[0]
+		p.Moves = p.Moves[1:]
+	}
+	p.Moves = append(p.Moves, move)
+	return forgotten, true
+}
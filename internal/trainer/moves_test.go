@@ -0,0 +1,25 @@
+package trainer
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLearnMove(t *testing.T) {
+	p := Pokemon{Moves: []string{"tackle", "growl", "tail-whip"}}
+
+	if forgotten, learned := p.LearnMove("growl"); learned || forgotten != "" {
+		t.Error("expected a known move not to be learned again")
+	}
+	if forgotten, learned := p.LearnMove("ember"); !learned || forgotten != "" {
+		t.Errorf("expected ember to fill the fourth slot, forgot %q", forgotten)
+	}
+	if forgotten, learned := p.LearnMove("smokescreen"); !learned || forgotten != "tackle" {
+		t.Errorf("expected tackle to be forgotten, got %q", forgotten)
+	}
+
+	expected := []string{"growl", "tail-whip", "ember", "smokescreen"}
+	if !slices.Equal(p.Moves, expected) {
+		t.Errorf("expected moves %v, got %v", expected, p.Moves)
+	}
+}
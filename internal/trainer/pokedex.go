@@ -0,0 +1,31 @@
+package trainer
+
+type DexStatus string
+
+const (
+	Seen   DexStatus = "seen"
+	Caught DexStatus = "caught"
+)
+
+// Pokedex records, per species, whether the player has seen or caught it.
+// Caught implies seen.
+type Pokedex map[string]DexStatus
+
+func (d Pokedex) MarkSeen(species string) {
+	if _, ok := d[species]; !ok {
+		d[species] = Seen
+	}
+}
+
+func (d Pokedex) MarkCaught(species string) {
+	d[species] = Caught
+}
+
+func (d Pokedex) HasSeen(species string) bool {
+	_, ok := d[species]
+	return ok
+}
+
+func (d Pokedex) HasCaught(species string) bool {
+	return d[species] == Caught
+}
@@ -0,0 +1,103 @@
+package trainer
+
+import (
+	"fmt"
+)
+
+const (
+	MaxIV       = 31
+	MaxStatEV   = 252
+	MaxTotalEVs = 510
+)
+
+// StatNames lists the six stats in PokeAPI's naming and display order.
+var StatNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// Stats maps stat names to values.
+type Stats map[string]int
+
+// Nature raises one stat by 10% and lowers another by 10%. Neutral
+// natures leave both empty.
+type Nature struct {
+	Name      string `json:"name"`
+	Increased string `json:"increased,omitempty"`
+	Decreased string `json:"decreased,omitempty"`
+}
+
+// RandomIVs rolls each individual value uniformly from 0 to 31.
+func RandomIVs(intn func(n int) int) Stats {
+	ivs := make(Stats, len(StatNames))
+	for _, stat := range StatNames {
+		ivs[stat] = intn(MaxIV + 1)
+	}
+	return ivs
+}
+
+// ActualStat applies the standard stat formula from Generation III
+// onwards. Shedinja's base HP of 1 always gives 1 HP.
+func ActualStat(stat string, base, iv, ev, level int, nature Nature) int {
+	core := (2*base + iv + ev/4) * level / 100
+	if stat == "hp" {
+		if base == 1 {
+			return 1
+		}
+		return core + level + 10
+	}
+
+	value := core + 5
+	switch stat {
+	case nature.Increased:
+		if nature.Increased != nature.Decreased {
+			value = value * 110 / 100
+		}
+	case nature.Decreased:
+		value = value * 90 / 100
+	}
+	return value
+}
+
+// ActualStats computes every stat of p from its species' base stats.
+func (p Pokemon) ActualStats(base Stats) Stats {
+	stats := make(Stats, len(StatNames))
+	for _, stat := range StatNames {
+		stats[stat] = ActualStat(stat, base[stat], p.IVs[stat], p.EVs[stat], p.Level, p.Nature)
+	}
+	return stats
+}
+
+// GainEffort adds effort values, respecting the per-stat and total caps.
+func (p *Pokemon) GainEffort(yield Stats) {
+	if p.EVs == nil {
+		p.EVs = Stats{}
+	}
+
+	total := 0
+	for _, ev := range p.EVs {
+		total += ev
+	}
+
+	for _, stat := range StatNames {
+		gain := min(yield[stat], MaxStatEV-p.EVs[stat], MaxTotalEVs-total)
+		if gain <= 0 {
+			continue
+		}
+		p.EVs[stat] += gain
+		total += gain
+	}
+}
+
+// ValidateIV rejects individual values outside 0 to 31.
+func ValidateIV(iv int) error {
+	if iv < 0 || iv > MaxIV {
+		return fmt.Errorf("IVs must be between 0 and %d", MaxIV)
+	}
+	return nil
+}
+
+// ValidateEV rejects effort values outside 0 to 252.
+func ValidateEV(ev int) error {
+	if ev < 0 || ev > MaxStatEV {
+		return fmt.Errorf("EVs must be between 0 and %d", MaxStatEV)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package trainer
+
+import (
+	"testing"
+)
+
+func TestActualStat(t *testing.T) {
+	// Garchomp at level 78 from Bulbapedia's worked example: Adamant, with
+	// IVs 24/12/30/16/23/5 and EVs 74/190/91/48/84/23.
+	adamant := Nature{Name: "adamant", Increased: "attack", Decreased: "special-attack"}
+	cases := []struct {
+		stat                string
+		base, iv, ev, level int
+		expected            int
+	}{
+		{"hp", 108, 24, 74, 78, 289},
+		{"attack", 130, 12, 190, 78, 278},
+		{"defense", 95, 30, 91, 78, 193},
+		{"special-attack", 80, 16, 48, 78, 135},
+		{"special-defense", 85, 23, 84, 78, 171},
+		{"speed", 102, 5, 23, 78, 171},
+		{"hp", 1, 31, 252, 100, 1},
+	}
+
+	for _, c := range cases {
+		if got := ActualStat(c.stat, c.base, c.iv, c.ev, c.level, adamant); got != c.expected {
+			t.Errorf("ActualStat(%s) = %d, expected %d", c.stat, got, c.expected)
+		}
+	}
+}
+
+func TestRandomIVs(t *testing.T) {
+	ivs := RandomIVs(func(n int) int { return n - 1 })
+	for _, stat := range StatNames {
+		if ivs[stat] != MaxIV {
+			t.Errorf("expected %s IV %d, got %d", stat, MaxIV, ivs[stat])
+		}
+	}
+}
+
+func TestGainEffort_Caps(t *testing.T) {
+	p := Pokemon{EVs: Stats{"attack": 250, "speed": 252}}
+
+	p.GainEffort(Stats{"attack": 3, "defense": 2})
+	if p.EVs["attack"] != MaxStatEV || p.EVs["defense"] != 2 {
+		t.Errorf("expected attack capped at %d, got %v", MaxStatEV, p.EVs)
+	}
+
+	p.GainEffort(Stats{"hp": 252})
+	if p.EVs["hp"] != 4 {
+		t.Errorf("expected hp limited by the %d total, got %d", MaxTotalEVs, p.EVs["hp"])
+	}
+}
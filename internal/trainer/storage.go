@@ -0,0 +1,202 @@
+package trainer
+
+import (
+	"fmt"
+)
+
+const (
+	PartySize = 6
+	BoxSize   = 30
+)
+
+// Place describes where an individual is kept: in the party, or in a PC
+// box (numbered from 1).
+type Place struct {
+	Box   int
+	Index int
+}
+
+func (p Place) InParty() bool {
+	return p.Box == 0
+}
+
+func (p Place) String() string {
+	if p.InParty() {
+		return "party"
+	}
+	return fmt.Sprintf("Box %d", p.Box)
+}
+
+// Get returns the individual with the given ID.
+func (c *Collection) Get(id int) (*Pokemon, bool) {
+	for i := range c.Pokemon {
+		if c.Pokemon[i].ID == id {
+			return &c.Pokemon[i], true
+		}
+	}
+	return nil, false
+}
+
+// PartyMembers returns the party in order, lead first.
+func (c *Collection) PartyMembers() []Pokemon {
+	return c.members(c.Party)
+}
+
+// BoxMembers returns the contents of box n (numbered from 1).
+func (c *Collection) BoxMembers(n int) ([]Pokemon, error) {
+	if n < 1 || n > len(c.Boxes) {
+		return nil, fmt.Errorf("there is no Box %d (you have %d)", n, len(c.Boxes))
+	}
+	return c.members(c.Boxes[n-1]), nil
+}
+
+func (c *Collection) members(ids []int) []Pokemon {
+	members := make([]Pokemon, 0, len(ids))
+	for _, id := range ids {
+		if p, ok := c.Get(id); ok {
+			members = append(members, *p)
+		}
+	}
+	return members
+}
+
+// Locate finds where an individual is kept.
+func (c *Collection) Locate(id int) (Place, bool) {
+	for i, partyID := range c.Party {
+		if partyID == id {
+			return Place{Box: 0, Index: i}, true
+		}
+	}
+	for b, box := range c.Boxes {
+		for i, boxID := range box {
+			if boxID == id {
+				return Place{Box: b + 1, Index: i}, true
+			}
+		}
+	}
+	return Place{}, false
+}
+
+// store puts a new individual in the party, or the first box with room
+// once the party is full.
+func (c *Collection) store(id int) Place {
+	if len(c.Party) < PartySize {
+		c.Party = append(c.Party, id)
+		return Place{Box: 0, Index: len(c.Party) - 1}
+	}
+	return c.storeInBox(id)
+}
+
+func (c *Collection) storeInBox(id int) Place {
+	for b := range c.Boxes {
+		if len(c.Boxes[b]) < BoxSize {
+			c.Boxes[b] = append(c.Boxes[b], id)
+			return Place{Box: b + 1, Index: len(c.Boxes[b]) - 1}
+		}
+	}
+	c.Boxes = append(c.Boxes, []int{id})
+	return Place{Box: len(c.Boxes), Index: 0}
+}
+
+func (c *Collection) unstore(id int) {
+	place, ok := c.Locate(id)
+	if !ok {
+		return
+	}
+	if place.InParty() {
+		c.Party = append(c.Party[:place.Index], c.Party[place.Index+1:]...)
+		return
+	}
+	box := c.Boxes[place.Box-1]
+	c.Boxes[place.Box-1] = append(box[:place.Index], box[place.Index+1:]...)
+}
+
+// Deposit moves a party member into the first box with room.
+func (c *Collection) Deposit(id int) (Place, error) {
+	place, ok := c.Locate(id)
+	if !ok {
+		return Place{}, fmt.Errorf("you have no Pokémon with ID %d", id)
+	}
+	if !place.InParty() {
+		return Place{}, fmt.Errorf("#%d is already in %s", id, place)
+	}
+	if len(c.Party) == 1 {
+		return Place{}, fmt.Errorf("you can't deposit your last party Pokémon")
+	}
+
+	c.unstore(id)
+	return c.storeInBox(id), nil
+}
+
+// Withdraw moves a boxed individual to the end of the party.
+func (c *Collection) Withdraw(id int) error {
+	place, ok := c.Locate(id)
+	if !ok {
+		return fmt.Errorf("you have no Pokémon with ID %d", id)
+	}
+	if place.InParty() {
+		return fmt.Errorf("#%d is already in your party", id)
+	}
+	if len(c.Party) >= PartySize {
+		return fmt.Errorf("your party is full; deposit or swap a Pokémon first")
+	}
+
+	c.unstore(id)
+	c.Party = append(c.Party, id)
+	return nil
+}
+
+// Swap exchanges the places of two individuals, which reorders the party
+// or trades a party member for a boxed one.
+func (c *Collection) Swap(a, b int) error {
+	placeA, ok := c.Locate(a)
+	if !ok {
+		return fmt.Errorf("you have no Pokémon with ID %d", a)
+	}
+	placeB, ok := c.Locate(b)
+	if !ok {
+		return fmt.Errorf("you have no Pokémon with ID %d", b)
+	}
+
+	*c.slot(placeA), *c.slot(placeB) = b, a
+	return nil
+}
+
+func (c *Collection) slot(place Place) *int {
+	if place.InParty() {
+		return &c.Party[place.Index]
+	}
+	return &c.Boxes[place.Box-1][place.Index]
+}
+
+// Normalize drops stale IDs from the party and boxes and stores any
+// individual that has no place yet, such as those from older save files.
+func (c *Collection) Normalize() {
+	owned := make(map[int]bool, len(c.Pokemon))
+	for _, p := range c.Pokemon {
+		owned[p.ID] = true
+	}
+
+	seen := make(map[int]bool, len(c.Pokemon))
+	keep := func(ids []int) []int {
+		kept := []int{}
+		for _, id := range ids {
+			if owned[id] && !seen[id] {
+				kept = append(kept, id)
+				seen[id] = true
+			}
+		}
+		return kept
+	}
+
+	c.Party = keep(c.Party)
+	for b := range c.Boxes {
+		c.Boxes[b] = keep(c.Boxes[b])
+	}
+
+	for _, p := range c.Pokemon {
+		if !seen[p.ID] {
+			c.store(p.ID)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package trainer
+
+import (
+	"testing"
+)
+
+func fillCollection(n int) *Collection {
+	c := &Collection{}
+	for i := 0; i < n; i++ {
+		c.Add(Pokemon{Species: "magikarp"})
+	}
+	return c
+}
+
+func TestAdd_OverflowsToBoxes(t *testing.T) {
+	c := fillCollection(PartySize + BoxSize + 1)
+
+	if len(c.Party) != PartySize {
+		t.Errorf("expected a full party of %d, got %d", PartySize, len(c.Party))
+	}
+	if len(c.Boxes) != 2 || len(c.Boxes[0]) != BoxSize || len(c.Boxes[1]) != 1 {
+		t.Errorf("expected one full box and one with a single Pokémon, got %d boxes", len(c.Boxes))
+	}
+
+	place, ok := c.Locate(PartySize + BoxSize + 1)
+	if !ok || place.Box != 2 || place.String() != "Box 2" {
+		t.Errorf("expected the last catch in Box 2, got %+v", place)
+	}
+}
+
+func TestDepositWithdraw(t *testing.T) {
+	c := fillCollection(2)
+
+	place, err := c.Deposit(1)
+	if err != nil {
+		t.Fatalf("Deposit failed: %v", err)
+	}
+	if place.Box != 1 || len(c.Party) != 1 || c.Party[0] != 2 {
+		t.Errorf("expected #1 in Box 1 and #2 alone in the party, got party %v", c.Party)
+	}
+
+	if _, err := c.Deposit(2); err == nil {
+		t.Error("expected depositing the last party member to fail")
+	}
+	if _, err := c.Deposit(1); err == nil {
+		t.Error("expected depositing a boxed Pokémon to fail")
+	}
+
+	if err := c.Withdraw(1); err != nil {
+		t.Fatalf("Withdraw failed: %v", err)
+	}
+	if len(c.Party) != 2 || c.Party[1] != 1 || len(c.Boxes[0]) != 0 {
+		t.Errorf("expected #1 back at the end of the party, got party %v boxes %v", c.Party, c.Boxes)
+	}
+
+	full := fillCollection(PartySize + 1)
+	if err := full.Withdraw(PartySize + 1); err == nil {
+		t.Error("expected withdrawing into a full party to fail")
+	}
+}
+
+func TestSwap(t *testing.T) {
+	c := fillCollection(PartySize + 1)
+
+	if err := c.Swap(1, 3); err != nil {
+		t.Fatalf("Swap failed: %v", err)
+	}
+	if c.Party[0] != 3 || c.Party[2] != 1 {
+		t.Errorf("expected party order to be swapped, got %v", c.Party)
+	}
+
+	if err := c.Swap(2, PartySize+1); err != nil {
+		t.Fatalf("Swap failed: %v", err)
+	}
+	if c.Party[1] != PartySize+1 || c.Boxes[0][0] != 2 {
+		t.Errorf("expected a party/box exchange, got party %v boxes %v", c.Party, c.Boxes)
+	}
+
+	if err := c.Swap(1, 99); err == nil {
+		t.Error("expected swapping with an unknown ID to fail")
+	}
+}
+
+func TestRelease_LastPartyMember(t *testing.T) {
+	c := fillCollection(1)
+	if _, err := c.Release(1); err == nil {
+		t.Error("expected releasing the last party member to fail")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	c := &Collection{
+		NextID:  4,
+		Pokemon: []Pokemon{{ID: 1}, {ID: 2}, {ID: 3}},
+		Party:   []int{2, 9, 2},
+	}
+
+	c.Normalize()
+
+	if len(c.Party) != 3 || c.Party[0] != 2 || c.Party[1] != 1 || c.Party[2] != 3 {
+		t.Errorf("expected stale and duplicate IDs dropped and unplaced Pokémon added, got %v", c.Party)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+type levelMove struct {
+	Name  string
+	Level int
+}
+
+// versionGroup maps the configured game version to the version group that
+// move learnsets are listed under. Without a game version it is empty.
+func versionGroup(cfg *Config) (string, error) {
+	if cfg.GameVersion == "" {
+		return "", nil
+	}
+
+	version, err := cfg.PokeapiClient.GetVersion(cfg.GameVersion)
+	if err != nil {
+		return "", err
+	}
+	return version.VersionGroup.Name, nil
+}
+
+// levelUpMoves lists the moves pokemon learns by levelling up in a version
+// group, ordered by level. When the version group has none, or is empty,
+// the most recent level listed for each move is used instead.
+func levelUpMoves(pokemon pokeapi.Pokemon, group string) []levelMove {
+	var inGroup, latest []levelMove
+	for _, moveEntry := range pokemon.Moves {
+		found, foundLatest := false, false
+		var last levelMove
+		for _, detail := range moveEntry.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name != "level-up" {
+				continue
+			}
+			last, foundLatest = levelMove{Name: moveEntry.Move.Name, Level: detail.LevelLearnedAt}, true
+			if detail.VersionGroup.Name == group && !found {
+				inGroup = append(inGroup, last)
+				found = true
+			}
+		}
+		if foundLatest {
+			latest = append(latest, last)
+		}
+	}
+
+	moves := inGroup
+	if len(moves) == 0 {
+		moves = latest
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		return moves[i].Level < moves[j].Level
+	})
+	return moves
+}
+
+// startingMoves gives a wild Pokémon the last four moves it would have
+// learned by its level, as in the main series.
+func startingMoves(pokemon pokeapi.Pokemon, group string, level int) []string {
+	var moves []string
+	for _, move := range levelUpMoves(pokemon, group) {
+		if move.Level > level {
+			break
+		}
+		moves = append(moves, move.Name)
+	}
+	if len(moves) > trainer.MaxMoves {
+		moves = moves[len(moves)-trainer.MaxMoves:]
+	}
+	return moves
+}
+
+// movesLearnedAt lists the moves pokemon learns on reaching level.
+func movesLearnedAt(pokemon pokeapi.Pokemon, group string, level int) []string {
+	var moves []string
+	for _, move := range levelUpMoves(pokemon, group) {
+		if move.Level == level {
+			moves = append(moves, move.Name)
+		}
+	}
+	return moves
+}
+
+// moveChange reports a move learned and, if the individual already knew
+// four, the one it forgot.
+type moveChange struct {
+	Learned   string `json:"learned"`
+	Forgotten string `json:"forgotten,omitempty"`
+}
+
+func teachMoves(individual *trainer.Pokemon, moves []string) []moveChange {
+	changes := []moveChange{}
+	for _, move := range moves {
+		if forgotten, learned := individual.LearnMove(move); learned {
+			changes = append(changes, moveChange{Learned: move, Forgotten: forgotten})
+		}
+	}
+	return changes
+}
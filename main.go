@@ -1,5 +1,188 @@
 package main
 
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/GrahamZiervogel/pokedex/internal/capture"
+	"github.com/GrahamZiervogel/pokedex/internal/cassette"
+	"github.com/GrahamZiervogel/pokedex/internal/config"
+	"github.com/GrahamZiervogel/pokedex/internal/mirror"
+	"github.com/GrahamZiervogel/pokedex/internal/output"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+)
+
+// settingFlags maps command-line flags to the config file keys they
+// override.
+var settingFlags = map[string]string{
+	"base-url":       "base_url",
+	"timeout":        "http.timeout",
+	"cache-interval": "cache.interval",
+	"save":           "save_path",
+	"data-dir":       "data_dir",
+	"language":       "language",
+	"game-version":   "game_version",
+	"output":         "output",
+	"catch-rules":    "catch_rules",
+	"seed":           "seed",
+}
+
 func main() {
-	startRepl()
+	if len(os.Args) > 1 {
+		subcommands := map[string]func([]string) error{
+			"mock-server": runMockServer,
+			"sync":        runSync,
+		}
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	scriptPath := flag.String("script", "", "run commands from `file` and exit")
+	stopOnError := flag.Bool("stop-on-error", false, "stop a --script run at the first failing command")
+	echo := flag.Bool("echo", false, "print each --script command before running it")
+	configPath := flag.String("config", "", "read settings from `file` (default $POKEDEX_CONFIG or "+config.DefaultPath()+")")
+	noColor := flag.Bool("no-color", false, "disable colored output (also honors NO_COLOR)")
+	recordDir := flag.String("record", "", "save every PokeAPI response to cassette `dir`")
+	replayDir := flag.String("replay", "", "answer PokeAPI requests only from cassette `dir`, without network access")
+	flag.String("base-url", "", "PokeAPI base `URL`, for example a self-hosted instance")
+	flag.String("timeout", "", "HTTP request `timeout`, such as 5s")
+	flag.String("cache-interval", "", "how long to keep cached responses, such as 5m")
+	flag.String("save", "", "save file `path`")
+	flag.String("data-dir", "", "read resources downloaded by \"pokedex sync\" from `dir`")
+	flag.String("language", "", "language for localized names, such as en or ja")
+	flag.String("game-version", "", "game version for encounter data, such as red or firered")
+	flag.String("output", "", "output `format`: text, json, yaml or table")
+	flag.String("seed", "", "random `seed` for reproducible encounters and catches (default: time-based)")
+	flag.String("catch-rules", "", "catch formula `generation`: "+strings.Join(capture.Names(), " or "))
+	flag.Parse()
+
+	settings, err := loadSettings(*configPath, *noColor)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	format, err := output.ParseFormat(settings.Output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	cfg := newConfig(settings)
+	cfg.OutputFormat = format
+	if err := setTransport(cfg, settings.DataDir, *recordDir, *replayDir); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	cfg.Palette = style.Palette{Mode: style.Detect(os.Stdout, !settings.Color)}
+
+	if err := loadPlayerState(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *scriptPath != "" {
+		err := runScriptFile(cfg, *scriptPath, scriptOptions{
+			stopOnError: *stopOnError,
+			echo:        *echo,
+		})
+		if errors.Is(err, errExit) {
+			// exit has already saved, unless told not to.
+			return
+		}
+		if saveErr := savePlayerState(cfg); saveErr != nil {
+			fmt.Fprintln(os.Stderr, saveErr)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	startRepl(cfg)
+}
+
+// setTransport switches the PokeAPI client to recording or replaying HTTP
+// exchanges when --record or --replay is given. Otherwise resources found
+// in the data directory are read from there instead of the network.
+func setTransport(cfg *Config, dataDir, recordDir, replayDir string) error {
+	switch {
+	case recordDir != "" && replayDir != "":
+		return fmt.Errorf("--record and --replay cannot be used together")
+	case recordDir != "":
+		cfg.PokeapiClient.SetTransport(cassette.NewRecorder(recordDir))
+	case replayDir != "":
+		info, err := os.Stat(replayDir)
+		if err != nil {
+			return fmt.Errorf("--replay: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("--replay: %s is not a directory", replayDir)
+		}
+		cfg.PokeapiClient.SetTransport(cassette.NewReplayer(replayDir))
+	case dataDir != "":
+		source, err := mirror.NewSource(dataDir, http.DefaultTransport)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("data directory: %w", err)
+		}
+		cfg.PokeapiClient.SetTransport(source)
+	}
+	return nil
+}
+
+// loadSettings layers the defaults, the config file, POKEDEX_* environment
+// variables and any flags given on the command line, in that order.
+func loadSettings(configPath string, noColor bool) (config.Settings, error) {
+	settings := config.Defaults()
+
+	optional := false
+	if configPath == "" {
+		configPath = os.Getenv("POKEDEX_CONFIG")
+	}
+	if configPath == "" {
+		configPath = config.DefaultPath()
+		optional = true
+	}
+	if configPath != "" {
+		if err := settings.LoadFile(configPath, optional); err != nil {
+			return config.Settings{}, err
+		}
+	}
+
+	if err := settings.ApplyEnv(os.Getenv); err != nil {
+		return config.Settings{}, err
+	}
+
+	var flagErr error
+	flag.Visit(func(f *flag.Flag) {
+		key, ok := settingFlags[f.Name]
+		if !ok || flagErr != nil {
+			return
+		}
+		if err := settings.Set(key, f.Value.String()); err != nil {
+			flagErr = fmt.Errorf("--%s: %w", f.Name, err)
+		}
+	})
+	if flagErr != nil {
+		return config.Settings{}, flagErr
+	}
+
+	if noColor {
+		settings.Color = false
+	}
+
+	return settings, nil
 }
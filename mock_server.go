@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapitest"
+)
+
+// runMockServer serves the PokeAPI fixtures over HTTP until the process is
+// stopped, so the REPL can be pointed at it with --base-url for offline
+// demos.
+func runMockServer(args []string) error {
+	fs := flag.NewFlagSet("mock-server", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:8000", "listen `address`")
+	fixtures := fs.String("fixtures", "", "serve fixtures from `dir` instead of the built-in set")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("mock-server: unexpected argument %q", fs.Arg(0))
+	}
+
+	server := pokeapitest.New()
+	if *fixtures != "" {
+		if _, err := os.Stat(*fixtures); err != nil {
+			return fmt.Errorf("mock-server: %w", err)
+		}
+		var err error
+		server, err = pokeapitest.NewFromFS(os.DirFS(*fixtures))
+		if err != nil {
+			return fmt.Errorf("mock-server: %w", err)
+		}
+	}
+
+	fmt.Printf("Serving PokeAPI fixtures on http://%s (use --base-url http://%s)\n", *addr, *addr)
+	return http.ListenAndServe(*addr, server)
+}
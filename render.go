@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GrahamZiervogel/pokedex/internal/output"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+)
+
+// result is the value a command produces. Every result can describe itself
+// as plain text and as a table; JSON and YAML are derived from its exported
+// fields.
+type result interface {
+	writeText(w io.Writer, p style.Palette)
+	table() ([]string, [][]string)
+}
+
+type messageResult struct {
+	Message string `json:"message"`
+}
+
+func (r messageResult) writeText(w io.Writer, _ style.Palette) {
+	fmt.Fprintln(w, r.Message)
+}
+
+func (r messageResult) table() ([]string, [][]string) {
+	return []string{"message"}, [][]string{{r.Message}}
+}
+
+func renderResult(w io.Writer, format output.Format, p style.Palette, res result) error {
+	switch format {
+	case output.JSON:
+		return output.WriteJSON(w, res)
+	case output.YAML:
+		return output.WriteYAML(w, res)
+	case output.Table:
+		headers, rows := res.table()
+		return output.WriteTable(w, headers, rows)
+	default:
+		res.writeText(w, p)
+		return nil
+	}
+}
+
+// progressf prints an informational line such as "Fetching..." that is
+// only useful to someone reading text output.
+func (cfg *Config) progressf(format string, args ...any) {
+	if cfg.outputFormat() != output.Text {
+		return
+	}
+	fmt.Fprintf(cfg.Out, format, args...)
+}
+
+// outputFormat is the format for the command currently running: a
+// per-command --output flag wins over the global setting.
+func (cfg *Config) outputFormat() output.Format {
+	if cfg.commandOutputFormat != "" {
+		return cfg.commandOutputFormat
+	}
+	if cfg.OutputFormat == "" {
+		return output.Text
+	}
+	return cfg.OutputFormat
+}
+
+// extractOutputFlag removes a --output option from args so individual
+// commands never have to handle it.
+func extractOutputFlag(args []string) ([]string, output.Format, error) {
+	remaining := []string{}
+	var format output.Format
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		var value string
+		switch {
+		case arg == "--output":
+			if i+1 >= len(args) {
+				return nil, "", fmt.Errorf("option --output requires a value")
+			}
+			i++
+			value = args[i]
+		case strings.HasPrefix(arg, "--output="):
+			value = strings.TrimPrefix(arg, "--output=")
+		default:
+			remaining = append(remaining, arg)
+			continue
+		}
+
+		parsed, err := output.ParseFormat(value)
+		if err != nil {
+			return nil, "", err
+		}
+		format = parsed
+	}
+
+	return remaining, format, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/config"
+	"github.com/GrahamZiervogel/pokedex/internal/output"
+)
+
+func TestExtractOutputFlag(t *testing.T) {
+	args, format, err := extractOutputFlag([]string{"pikachu", "--output", "yaml"})
+	if err != nil {
+		t.Fatalf("extractOutputFlag failed: %v", err)
+	}
+	if format != output.YAML {
+		t.Errorf("expected yaml format, got %q", format)
+	}
+	if len(args) != 1 || args[0] != "pikachu" {
+		t.Errorf("expected remaining args [pikachu], got %v", args)
+	}
+
+	if _, _, err := extractOutputFlag([]string{"--output=xml"}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestRunCommand_OutputFormats(t *testing.T) {
+	cfg := newConfig(config.Defaults())
+	cfg.Pokedex.MarkCaught("pikachu")
+	cfg.Pokedex.MarkSeen("bulbasaur")
+
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "pokedex",
+			expected: "Your Pokedex:\n - bulbasaur (seen)\n - pikachu\nSeen: 2, Caught: 1\n",
+		},
+		{
+			input:    "pokedex --output json",
+			expected: "{\n  \"seen\": 2,\n  \"caught\": 1,\n  \"entries\": [\n    {\n      \"species\": \"bulbasaur\",\n      \"status\": \"seen\"\n    },\n    {\n      \"species\": \"pikachu\",\n      \"status\": \"caught\"\n    }\n  ]\n}\n",
+		},
+		{
+			input:    "pokedex --output yaml",
+			expected: "seen: 2\ncaught: 1\nentries:\n  - species: bulbasaur\n    status: seen\n  - species: pikachu\n    status: caught\n",
+		},
+		{
+			input:    "pokedex --output table",
+			expected: "SPECIES    STATUS\nbulbasaur  seen\npikachu    caught\n",
+		},
+	}
+
+	for _, c := range cases {
+		var b strings.Builder
+		cfg.Out = &b
+		if err := runCommand(cfg, c.input); err != nil {
+			t.Fatalf("%s: runCommand failed: %v", c.input, err)
+		}
+		if b.String() != c.expected {
+			t.Errorf("%s: expected output %q, got %q", c.input, c.expected, b.String())
+		}
+	}
+}
+
+func TestRunCommand_GlobalOutputFormat(t *testing.T) {
+	cfg := newConfig(config.Defaults())
+	var b strings.Builder
+	cfg.Out = &b
+
+	if err := runCommand(cfg, "output json"); err != nil {
+		t.Fatalf("output command failed: %v", err)
+	}
+	b.Reset()
+
+	if err := runCommand(cfg, "pokedex"); err != nil {
+		t.Fatalf("pokedex command failed: %v", err)
+	}
+	if b.String() != "{\n  \"seen\": 0,\n  \"caught\": 0,\n  \"entries\": []\n}\n" {
+		t.Errorf("expected JSON output after setting global format, got %q", b.String())
+	}
+}
@@ -2,74 +2,160 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/GrahamZiervogel/pokedex/internal/config"
+	"github.com/GrahamZiervogel/pokedex/internal/encounter"
+	"github.com/GrahamZiervogel/pokedex/internal/output"
 	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/style"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
 )
 
 type cliCommand struct {
-	name        string
-	description string
-	callback    func(cfg *Config, args ...string) error
+	name            string
+	description     string
+	callback        func(cfg *Config, args ...string) (result, error)
+	preserveArgCase bool
 }
 
 type Config struct {
 	PokeapiClient            *pokeapi.Client
 	NextLocationAreasURL     *string
 	PreviousLocationAreasURL *string
-	Pokedex                  map[string]pokeapi.Pokemon
+	Pokedex                  trainer.Pokedex
+	Collection               trainer.Collection
+	Inventory                trainer.Inventory
+	OutputFormat             output.Format
+	Out                      io.Writer
+	Palette                  style.Palette
+	SavePath                 string
+	Language                 string
+	GameVersion              string
+	CurrentArea              string
+	WildEncounter            *encounter.Encounter
+	PendingEvolutions        []pendingEvolution
+	Battle                   *wildBattle
+	CatchRules               string
+	Seed                     int64
+	Rand                     *rand.Rand
+
+	commandOutputFormat output.Format
+	scriptDepth         int
+	// scriptDir is the directory of the script being run, against which
+	// the scripts it sources are resolved.
+	scriptDir string
 }
 
-func startRepl() {
-	scanner := bufio.NewScanner(os.Stdin)
+var errUnknownCommand = errors.New("unknown command")
 
-	httpClientTimeout := 5 * time.Second
-	cacheReapInterval := 5 * time.Minute
+func newConfig(settings config.Settings) *Config {
+	pokeClient := pokeapi.NewClient(settings.HTTPTimeout, settings.CacheInterval)
+	pokeClient.SetBaseURL(settings.BaseURL)
 
-	pokeClient := pokeapi.NewClient(httpClientTimeout, cacheReapInterval)
+	seed := time.Now().UnixNano()
+	if settings.Seed != nil {
+		seed = *settings.Seed
+	}
 
-	cfg := &Config{
+	return &Config{
 		PokeapiClient: pokeClient,
-		Pokedex:       make(map[string]pokeapi.Pokemon),
+		Pokedex:       trainer.Pokedex{},
+		Inventory:     trainer.NewInventory(),
+		OutputFormat:  output.Text,
+		Out:           os.Stdout,
+		SavePath:      settings.SavePath,
+		Language:      settings.Language,
+		GameVersion:   settings.GameVersion,
+		CatchRules:    settings.CatchRules,
+		Seed:          seed,
+		Rand:          rand.New(rand.NewSource(seed)),
 	}
+}
+
+func startRepl(cfg *Config) {
+	scanner := bufio.NewScanner(os.Stdin)
 
 	for {
-		fmt.Print("Pokedex > ")
+		fmt.Print(prompt(cfg))
 
 		if !scanner.Scan() {
 			if err := scanner.Err(); err != nil {
 				fmt.Fprintln(os.Stderr, "Error reading input:", err)
 			}
 			fmt.Println("\nExiting Pokedex REPL.")
+			if err := savePlayerState(cfg); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
 			break
 		}
 
-		userInput := scanner.Text()
-		cleanedWords := cleanInput(userInput)
-
-		if len(cleanedWords) == 0 {
-			continue
+		err := runCommand(cfg, scanner.Text())
+		if errors.Is(err, errExit) {
+			break
 		}
-
-		commandName := cleanedWords[0]
-		args := []string{}
-		if len(cleanedWords) > 1 {
-			args = cleanedWords[1:]
+		if err != nil {
+			fmt.Fprintln(cfg.Out, err)
 		}
+	}
+}
 
-		command, exists := getCommands()[commandName]
-		if exists {
-			err := command.callback(cfg, args...)
-			if err != nil {
-				fmt.Println(err)
-			}
-		} else {
-			fmt.Println("Unknown command")
+// prompt shows the player's current location area, if any.
+func prompt(cfg *Config) string {
+	if cfg.CurrentArea == "" {
+		return "Pokedex > "
+	}
+	return fmt.Sprintf("Pokedex (%s) > ", cfg.CurrentArea)
+}
+
+// runCommand parses a single line of input and dispatches it to the
+// matching command. Blank input is a no-op.
+func runCommand(cfg *Config, userInput string) error {
+	cleanedWords := cleanInput(userInput)
+
+	if len(cleanedWords) == 0 {
+		return nil
+	}
+
+	commandName := cleanedWords[0]
+	command, exists := getCommands()[commandName]
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownCommand, commandName)
+	}
+
+	args := []string{}
+	if len(cleanedWords) > 1 {
+		args = cleanedWords[1:]
+		if command.preserveArgCase {
+			args = strings.Fields(userInput)[1:]
 		}
 	}
+
+	args, format, err := extractOutputFlag(args)
+	if err != nil {
+		return err
+	}
+
+	previousFormat := cfg.commandOutputFormat
+	if format != "" {
+		cfg.commandOutputFormat = format
+	}
+	defer func() { cfg.commandOutputFormat = previousFormat }()
+
+	res, err := command.callback(cfg, args...)
+	if err != nil {
+		return err
+	}
+	if res == nil {
+		return nil
+	}
+	return renderResult(cfg.Out, cfg.outputFormat(), cfg.Palette, res)
 }
 
 func cleanInput(text string) []string {
@@ -87,7 +173,7 @@ func getCommands() map[string]cliCommand {
 			callback:    commandHelp,
 		},
 		"exit": {
-			name:        "exit",
+			name:        "exit [--no-save]",
 			description: "Exit the Pokedex",
 			callback:    commandExit,
 		},
@@ -106,20 +192,204 @@ func getCommands() map[string]cliCommand {
 			description: "Lists Pokémon in a given location area",
 			callback:    commandExplore,
 		},
+		"goto": {
+			name:        "goto [location_area_name]",
+			description: "Travel to a location area, or show where you are",
+			callback:    commandGoto,
+		},
+		"walk": {
+			name:        "walk",
+			description: "Walk through the tall grass looking for wild Pokémon",
+			callback:    commandWalk,
+		},
+		"fish": {
+			name:        "fish [old|good|super]",
+			description: "Cast a fishing rod for wild Pokémon",
+			callback:    commandFish,
+		},
+		"surf": {
+			name:        "surf",
+			description: "Surf across the water looking for wild Pokémon",
+			callback:    commandSurf,
+		},
 		"catch": {
-			name:        "catch <pokemon_name>",
-			description: "Attempt to catch a Pokémon and add it to your Pokedex",
+			name:        "catch [pokemon_name] [--ball name] [--status condition]",
+			description: "Attempt to catch the wild Pokémon you encountered",
 			callback:    commandCatch,
 		},
 		"inspect": {
-			name:        "inspect <pokemon_name>",
+			name:        "inspect <id|nickname|species> [--sprite]",
 			description: "View details of a caught Pokémon",
 			callback:    commandInspect,
 		},
 		"pokedex": {
-			name:        "pokedex",
-			description: "View all Pokémon you have caught",
+			name:        "pokedex [query]",
+			description: "View every species you have seen or caught, or search your Pokémon (e.g. type:water attack>80 sort:-speed limit:5)",
 			callback:    commandPokedex,
 		},
+		"progress": {
+			name:        "progress [pokedex or generation]",
+			description: "Show how much of each Pokédex and generation you have seen and caught, or what is missing from one",
+			callback:    commandProgress,
+		},
+		"collection": {
+			name:        "collection",
+			description: "List every individual Pokémon you have caught",
+			callback:    commandCollection,
+		},
+		"rename": {
+			name:            "rename <id|nickname|species> <nickname>",
+			description:     "Give a caught Pokémon a nickname (use \"-\" to clear it)",
+			callback:        commandRename,
+			preserveArgCase: true,
+		},
+		"release": {
+			name:        "release <id|nickname|species>",
+			description: "Release a caught Pokémon back into the wild",
+			callback:    commandRelease,
+		},
+		"party": {
+			name:        "party",
+			description: "List the Pokémon in your party",
+			callback:    commandParty,
+		},
+		"box": {
+			name:        "box [number]",
+			description: "List the Pokémon stored in a PC box",
+			callback:    commandBox,
+		},
+		"deposit": {
+			name:        "deposit <id|nickname|species>",
+			description: "Move a party Pokémon into a PC box",
+			callback:    commandDeposit,
+		},
+		"withdraw": {
+			name:        "withdraw <id|nickname|species>",
+			description: "Move a boxed Pokémon into your party",
+			callback:    commandWithdraw,
+		},
+		"swap": {
+			name:        "swap <pokemon> <pokemon>",
+			description: "Exchange the places of two Pokémon in your party or boxes",
+			callback:    commandSwap,
+		},
+		"stats": {
+			name:        "stats <pokemon> [--level N] [--nature X] [--iv N] [--ev N]",
+			description: "Calculate actual stats for a caught Pokémon or any species",
+			callback:    commandStats,
+		},
+		"mart": {
+			name:        "mart",
+			description: "List what the Poké Mart sells",
+			callback:    commandMart,
+		},
+		"buy": {
+			name:        "buy <item> [quantity]",
+			description: "Buy items from the Poké Mart",
+			callback:    commandBuy,
+		},
+		"sell": {
+			name:        "sell <item> [quantity]",
+			description: "Sell items to the Poké Mart for half price",
+			callback:    commandSell,
+		},
+		"bag": {
+			name:        "bag",
+			description: "List your money and the items in your bag",
+			callback:    commandBag,
+		},
+		"fight": {
+			name:        "fight <move>",
+			description: "Attack the wild Pokémon with one of your Pokémon's moves",
+			callback:    commandFight,
+		},
+		"item": {
+			name:        "item <item>",
+			description: "Use a potion on your Pokémon during a battle",
+			callback:    commandItem,
+		},
+		"switch": {
+			name:        "switch <pokemon>",
+			description: "Send out another party Pokémon during a battle",
+			callback:    commandSwitch,
+		},
+		"run": {
+			name:        "run",
+			description: "Try to run away from a wild battle",
+			callback:    commandRun,
+		},
+		"evolve": {
+			name:        "evolve",
+			description: "Let the Pokémon that is evolving finish evolving",
+			callback:    commandEvolve,
+		},
+		"cancel": {
+			name:        "cancel",
+			description: "Stop the Pokémon that is evolving from evolving",
+			callback:    commandCancel,
+		},
+		"use": {
+			name:        "use <item> <pokemon>",
+			description: "Use an item such as an evolution stone on a caught Pokémon",
+			callback:    commandUse,
+		},
+		"team": {
+			name:        "team analyze [pokemon...] [--party]",
+			description: "Report the type weaknesses, immunities and coverage gaps of your caught Pokémon, your party or the named Pokémon",
+			callback:    commandTeam,
+		},
+		"trade": {
+			name:        "trade <pokemon>",
+			description: "Trade a caught Pokémon to a friend and back",
+			callback:    commandTrade,
+		},
+		"compare": {
+			name:        "compare <pokemon> <pokemon> [pokemon...]",
+			description: "Compare the base stats, types and abilities of Pokémon side by side",
+			callback:    commandCompare,
+		},
+		"damage": {
+			name:        "damage <attacker> <move> <defender> [options]",
+			description: "Calculate the damage range of a move between two Pokémon",
+			callback:    commandDamage,
+		},
+		"sprite": {
+			name:        "sprite <pokemon_name> [--shiny] [--back]",
+			description: "Draw a Pokémon's sprite in the terminal",
+			callback:    commandSprite,
+		},
+		"save": {
+			name:        "save",
+			description: "Save your progress (also happens automatically on exit)",
+			callback:    commandSave,
+		},
+		"seed": {
+			name:        "seed [number]",
+			description: "Show the random seed, or reseed to replay exact outcomes",
+			callback:    commandSeed,
+		},
+		"export": {
+			name:            "export <file> [--format json|csv|showdown]",
+			description:     "Write every caught Pokémon to a file",
+			callback:        commandExport,
+			preserveArgCase: true,
+		},
+		"import": {
+			name:            "import <file> [--format json|csv|showdown]",
+			description:     "Add the Pokémon in a file to your collection, or none of them if any entry is invalid",
+			callback:        commandImport,
+			preserveArgCase: true,
+		},
+		"source": {
+			name:            "source <file> [--stop-on-error] [--echo]",
+			description:     "Run commands from a file, one per line",
+			callback:        commandSource,
+			preserveArgCase: true,
+		},
+		"output": {
+			name:        "output [text|json|yaml|table]",
+			description: "Show or set the output format for all commands (any command also accepts --output <format>)",
+			callback:    commandOutput,
+		},
 	}
 }
@@ -1,6 +1,11 @@
 package main
 
-import "testing"
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/config"
+)
 
 func TestCleanInput(t *testing.T) {
 	cases := []struct {
@@ -73,3 +78,18 @@ func TestCleanInput(t *testing.T) {
 		}
 	}
 }
+
+func TestNewConfig_SeedZero(t *testing.T) {
+	settings := config.Defaults()
+	if err := settings.Set("seed", "0"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	cfg := newConfig(settings)
+	if cfg.Seed != 0 {
+		t.Fatalf("expected seed 0 to be kept, got %d", cfg.Seed)
+	}
+	if got, want := cfg.Rand.Int63(), rand.New(rand.NewSource(0)).Int63(); got != want {
+		t.Errorf("expected the generator to be seeded with 0, got %d, want %d", got, want)
+	}
+}
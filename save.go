@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+const saveFileVersion = 2
+
+type saveFile struct {
+	Version     int                `json:"version"`
+	Pokedex     trainer.Pokedex    `json:"pokedex"`
+	Collection  trainer.Collection `json:"collection"`
+	CurrentArea string             `json:"current_area,omitempty"`
+	// Inventory is missing from saves made before the bag existed, which
+	// keep the starting inventory.
+	Inventory *trainer.Inventory `json:"inventory,omitempty"`
+}
+
+// saveFileV1 is the original format, which stored one full Pokémon record
+// per caught species.
+type saveFileV1 struct {
+	Pokedex     map[string]pokeapi.Pokemon `json:"pokedex"`
+	CurrentArea string                     `json:"current_area,omitempty"`
+}
+
+// loadPlayerState restores progress from cfg.SavePath. A missing save file
+// simply means a new game.
+func loadPlayerState(cfg *Config) error {
+	if cfg.SavePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(cfg.SavePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read save file: %w", err)
+	}
+
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return fmt.Errorf("could not parse save file %s: %w", cfg.SavePath, err)
+	}
+	if header.Version > saveFileVersion {
+		return fmt.Errorf("save file %s was written by a newer version of the Pokedex", cfg.SavePath)
+	}
+
+	var save saveFile
+	if header.Version < 2 {
+		save, err = migrateSaveV1(data)
+	} else {
+		err = json.Unmarshal(data, &save)
+	}
+	if err != nil {
+		return fmt.Errorf("could not parse save file %s: %w", cfg.SavePath, err)
+	}
+
+	if save.Pokedex != nil {
+		cfg.Pokedex = save.Pokedex
+	}
+	cfg.Collection = save.Collection
+	// Saves written before the party existed have no party or boxes yet.
+	cfg.Collection.Normalize()
+	cfg.CurrentArea = save.CurrentArea
+	if save.Inventory != nil {
+		cfg.Inventory = *save.Inventory
+	}
+	return nil
+}
+
+// migrateSaveV1 turns each species caught under the old format into a
+// caught individual. Version 1 saves did not record levels or catch
+// details, so those are left at their zero values apart from level 1.
+func migrateSaveV1(data []byte) (saveFile, error) {
+	var old saveFileV1
+	if err := json.Unmarshal(data, &old); err != nil {
+		return saveFile{}, err
+	}
+
+	names := make([]string, 0, len(old.Pokedex))
+	for name := range old.Pokedex {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	save := saveFile{
+		Version:     saveFileVersion,
+		Pokedex:     trainer.Pokedex{},
+		CurrentArea: old.CurrentArea,
+	}
+	for _, name := range names {
+		pokemon := old.Pokedex[name]
+		if pokemon.Name == "" {
+			pokemon.Name = name
+		}
+		species := pokemon.Species.Name
+		if species == "" {
+			species = pokemon.Name
+		}
+		// Like catch, the Pokedex is keyed by species while the
+		// individual keeps its Pokémon name, which differs for forms.
+		save.Pokedex.MarkCaught(species)
+		save.Collection.Add(trainer.Pokemon{Species: pokemon.Name, Level: 1})
+	}
+
+	return save, nil
+}
+
+// savePlayerState writes progress to cfg.SavePath, replacing the previous
+// save only once the new one is fully written.
+func savePlayerState(cfg *Config) error {
+	if cfg.SavePath == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(saveFile{
+		Version:     saveFileVersion,
+		Pokedex:     cfg.Pokedex,
+		Collection:  cfg.Collection,
+		CurrentArea: cfg.CurrentArea,
+		Inventory:   &cfg.Inventory,
+	})
+	if err != nil {
+		return fmt.Errorf("could not encode save data: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.SavePath), 0o755); err != nil {
+		return fmt.Errorf("could not create save directory: %w", err)
+	}
+
+	tmpPath := cfg.SavePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("could not write save file: %w", err)
+	}
+	if err := os.Rename(tmpPath, cfg.SavePath); err != nil {
+		return fmt.Errorf("could not write save file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/config"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+func TestPlayerStateRoundTrip(t *testing.T) {
+	settings := config.Defaults()
+	settings.SavePath = filepath.Join(t.TempDir(), "nested", "save.json")
+
+	cfg := newConfig(settings)
+	cfg.Pokedex.MarkCaught("pikachu")
+	cfg.Pokedex.MarkSeen("rattata")
+	cfg.Collection.Add(trainer.Pokemon{Species: "pikachu", Nickname: "Sparky", Level: 5})
+	cfg.CurrentArea = "viridian-forest-area"
+
+	if err := savePlayerState(cfg); err != nil {
+		t.Fatalf("savePlayerState failed: %v", err)
+	}
+
+	restored := newConfig(settings)
+	if err := loadPlayerState(restored); err != nil {
+		t.Fatalf("loadPlayerState failed: %v", err)
+	}
+	if !restored.Pokedex.HasCaught("pikachu") || !restored.Pokedex.HasSeen("rattata") {
+		t.Errorf("expected Pokedex to be restored, got %v", restored.Pokedex)
+	}
+	if len(restored.Collection.Pokemon) != 1 || restored.Collection.Pokemon[0].Nickname != "Sparky" {
+		t.Errorf("expected collection to be restored, got %+v", restored.Collection)
+	}
+	if restored.Collection.NextID != 2 {
+		t.Errorf("expected next ID to be restored, got %d", restored.Collection.NextID)
+	}
+	if restored.CurrentArea != "viridian-forest-area" {
+		t.Errorf("expected current area to be restored, got '%s'", restored.CurrentArea)
+	}
+}
+
+func TestLoadPlayerState_MigratesVersion1(t *testing.T) {
+	settings := config.Defaults()
+	settings.SavePath = filepath.Join(t.TempDir(), "save.json")
+	v1 := `{"version": 1, "pokedex": {
+		"pikachu": {"name": "pikachu", "species": {"name": "pikachu"}},
+		"deoxys-attack": {"name": "deoxys-attack", "species": {"name": "deoxys"}},
+		"bulbasaur": {"name": "bulbasaur", "species": {"name": "bulbasaur"}}
+	}}`
+	if err := os.WriteFile(settings.SavePath, []byte(v1), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := newConfig(settings)
+	if err := loadPlayerState(cfg); err != nil {
+		t.Fatalf("loadPlayerState failed: %v", err)
+	}
+
+	if !cfg.Pokedex.HasCaught("pikachu") || !cfg.Pokedex.HasCaught("bulbasaur") || !cfg.Pokedex.HasCaught("deoxys") {
+		t.Errorf("expected migrated species to be caught, got %v", cfg.Pokedex)
+	}
+	if cfg.Pokedex.HasCaught("deoxys-attack") {
+		t.Errorf("expected the Pokedex to be keyed by species rather than form, got %v", cfg.Pokedex)
+	}
+	collection := cfg.Collection.Pokemon
+	if len(collection) != 3 || collection[0].Species != "bulbasaur" || collection[0].ID != 1 || collection[1].Species != "deoxys-attack" {
+		t.Errorf("expected three migrated individuals in name order keeping their forms, got %+v", collection)
+	}
+}
+
+func TestLoadPlayerState_MissingFile(t *testing.T) {
+	settings := config.Defaults()
+	settings.SavePath = filepath.Join(t.TempDir(), "save.json")
+
+	cfg := newConfig(settings)
+	if err := loadPlayerState(cfg); err != nil {
+		t.Errorf("expected a missing save file to start a new game, got %v", err)
+	}
+}
+
+func TestLoadPlayerState_NewerVersion(t *testing.T) {
+	settings := config.Defaults()
+	settings.SavePath = filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(settings.SavePath, []byte(`{"version": 999}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := newConfig(settings)
+	if err := loadPlayerState(cfg); err == nil {
+		t.Error("expected an error for a save file from a newer version")
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const maxScriptDepth = 8
+
+type scriptOptions struct {
+	stopOnError bool
+	echo        bool
+}
+
+// runScriptFile runs the script at path. Inside another script, a relative
+// path is resolved against that script's directory rather than the
+// working directory.
+func runScriptFile(cfg *Config, path string, opts scriptOptions) error {
+	if cfg.scriptDir != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(cfg.scriptDir, path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open script: %w", err)
+	}
+	defer file.Close()
+
+	previousDir := cfg.scriptDir
+	cfg.scriptDir = filepath.Dir(path)
+	defer func() { cfg.scriptDir = previousDir }()
+
+	return runScript(cfg, file, path, opts)
+}
+
+// runScript feeds each line of r through the same dispatcher as the REPL.
+// Blank lines and lines starting with '#' are skipped. Errors are reported
+// with their line number; unless stopOnError is set, execution continues
+// and a summary error is returned at the end. An exit command stops the
+// script after printing that summary and returns errExit.
+func runScript(cfg *Config, r io.Reader, name string, opts scriptOptions) error {
+	if cfg.scriptDepth >= maxScriptDepth {
+		return fmt.Errorf("%s: scripts nested more than %d deep", name, maxScriptDepth)
+	}
+	cfg.scriptDepth++
+	defer func() { cfg.scriptDepth-- }()
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	commandCount := 0
+	failureCount := 0
+	exiting := false
+
+	for scanner.Scan() {
+		lineNumber++
+		line := stripComment(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		commandCount++
+		if opts.echo {
+			fmt.Fprintf(cfg.Out, "%s%s\n", prompt(cfg), line)
+		}
+
+		err := runCommand(cfg, line)
+		if errors.Is(err, errExit) {
+			exiting = true
+			break
+		}
+		if err != nil {
+			failureCount++
+			err = fmt.Errorf("%s:%d: %w", name, lineNumber, err)
+			if opts.stopOnError {
+				return err
+			}
+			fmt.Fprintln(cfg.Out, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading %s: %w", name, err)
+	}
+
+	var summary error
+	if failureCount > 0 {
+		summary = fmt.Errorf("%s: %d of %d commands failed", name, failureCount, commandCount)
+	}
+	if exiting {
+		if summary != nil {
+			fmt.Fprintln(cfg.Out, summary)
+		}
+		return errExit
+	}
+	return summary
+}
+
+func stripComment(line string) string {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "#") {
+		return ""
+	}
+	if i := strings.Index(line, " #"); i >= 0 {
+		line = strings.TrimSpace(line[:i])
+	}
+	return line
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GrahamZiervogel/pokedex/internal/config"
+)
+
+func quietConfig() *Config {
+	cfg := newConfig(config.Defaults())
+	cfg.Out = io.Discard
+	return cfg
+}
+
+func TestRunScript_SkipsCommentsAndBlankLines(t *testing.T) {
+	script := `
+# set up
+pokedex   # list what we have
+
+	# indented comment
+`
+	err := runScript(quietConfig(), strings.NewReader(script), "setup.txt", scriptOptions{})
+	if err != nil {
+		t.Fatalf("expected script to succeed, got %v", err)
+	}
+}
+
+func TestRunScript_ContinuesPastErrors(t *testing.T) {
+	script := "bogus\npokedex\nalsobogus\n"
+
+	err := runScript(quietConfig(), strings.NewReader(script), "run.txt", scriptOptions{})
+	if err == nil {
+		t.Fatal("expected a summary error, got nil")
+	}
+	if !strings.Contains(err.Error(), "2 of 3 commands failed") {
+		t.Errorf("expected failure summary, got '%s'", err.Error())
+	}
+}
+
+func TestRunScript_StopOnError(t *testing.T) {
+	script := "pokedex\nbogus\nalsobogus\n"
+
+	err := runScript(quietConfig(), strings.NewReader(script), "run.txt", scriptOptions{stopOnError: true})
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if !errors.Is(err, errUnknownCommand) {
+		t.Errorf("expected unknown command error, got '%s'", err.Error())
+	}
+	if !strings.HasPrefix(err.Error(), "run.txt:2:") {
+		t.Errorf("expected error to point at line 2, got '%s'", err.Error())
+	}
+}
+
+func TestRunScript_NestingLimit(t *testing.T) {
+	cfg := quietConfig()
+	cfg.scriptDepth = maxScriptDepth
+
+	err := runScript(cfg, strings.NewReader("pokedex\n"), "deep.txt", scriptOptions{})
+	if err == nil {
+		t.Fatal("expected nesting limit error, got nil")
+	}
+}
+
+func TestRunScriptFile_SourcesRelativeToScript(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "scripts")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeScript(t, filepath.Join(dir, "main.txt"), "source setup.txt\n")
+	writeScript(t, filepath.Join(dir, "setup.txt"), "seed 7\n")
+
+	cfg := quietConfig()
+	if err := runScriptFile(cfg, filepath.Join(dir, "main.txt"), scriptOptions{}); err != nil {
+		t.Fatalf("expected nested script to be found beside its parent, got %v", err)
+	}
+	if cfg.Seed != 7 {
+		t.Errorf("expected the nested script to run, seed is %d", cfg.Seed)
+	}
+	if cfg.scriptDir != "" {
+		t.Errorf("expected script directory to be reset, got '%s'", cfg.scriptDir)
+	}
+}
+
+func TestRunScript_ExitStopsScripts(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, filepath.Join(dir, "inner.txt"), "bogus\nexit --no-save\nseed 8\n")
+	writeScript(t, filepath.Join(dir, "outer.txt"), "seed 3\nsource inner.txt\nseed 9\n")
+
+	cfg := quietConfig()
+	var out bytes.Buffer
+	cfg.Out = &out
+
+	err := runScriptFile(cfg, filepath.Join(dir, "outer.txt"), scriptOptions{})
+	if !errors.Is(err, errExit) {
+		t.Fatalf("expected exit to reach the caller, got %v", err)
+	}
+	if cfg.Seed != 3 {
+		t.Errorf("expected no commands to run after exit, seed is %d", cfg.Seed)
+	}
+	if !strings.Contains(out.String(), "1 of 2 commands failed") {
+		t.Errorf("expected the inner script's summary before exiting, got '%s'", out.String())
+	}
+}
+
+func writeScript(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	spec := map[string]bool{"echo": false, "output": true}
+
+	positional, flags, err := parseFlags([]string{"a.txt", "--echo", "--output", "json", "b"}, spec)
+	if err != nil {
+		t.Fatalf("parseFlags failed: %v", err)
+	}
+	if len(positional) != 2 || positional[0] != "a.txt" || positional[1] != "b" {
+		t.Errorf("unexpected positional args %v", positional)
+	}
+	if flags["echo"] != "true" || flags["output"] != "json" {
+		t.Errorf("unexpected flags %v", flags)
+	}
+
+	_, flags, err = parseFlags([]string{"--output=yaml"}, spec)
+	if err != nil || flags["output"] != "yaml" {
+		t.Errorf("expected --output=yaml to parse, got %v (%v)", flags, err)
+	}
+
+	if _, _, err := parseFlags([]string{"--bogus"}, spec); err == nil {
+		t.Error("expected error for unknown option")
+	}
+	if _, _, err := parseFlags([]string{"--output"}, spec); err == nil {
+		t.Error("expected error for missing option value")
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GrahamZiervogel/pokedex/internal/pokeapi"
+	"github.com/GrahamZiervogel/pokedex/internal/trainer"
+)
+
+func baseStats(pokemon pokeapi.Pokemon) trainer.Stats {
+	stats := make(trainer.Stats, len(pokemon.Stats))
+	for _, statEntry := range pokemon.Stats {
+		stats[statEntry.Stat.Name] = statEntry.BaseStat
+	}
+	return stats
+}
+
+func effortYield(pokemon pokeapi.Pokemon) trainer.Stats {
+	stats := make(trainer.Stats, len(pokemon.Stats))
+	for _, statEntry := range pokemon.Stats {
+		stats[statEntry.Stat.Name] = statEntry.Effort
+	}
+	return stats
+}
+
+// statSubject is the caught individual ref names, or else a stand-in for
+// the species at level 50 with perfect IVs, no EVs and a neutral nature.
+// Only a name that matches nothing caught falls back to the species; an
+// unknown ID or a name shared by several individuals is an error.
+func statSubject(cfg *Config, ref string) (trainer.Pokemon, error) {
+	individual, err := cfg.Collection.Find(ref)
+	if err == nil {
+		return *individual, nil
+	}
+	var notCaught *trainer.NotCaughtError
+	if !errors.As(err, &notCaught) {
+		return trainer.Pokemon{}, err
+	}
+
+	subject := trainer.Pokemon{Species: strings.ToLower(ref), Level: 50, IVs: trainer.Stats{}}
+	for _, stat := range trainer.StatNames {
+		subject.IVs[stat] = trainer.MaxIV
+	}
+	return subject, nil
+}
+
+func parseLevel(value string) (int, error) {
+	level, err := strconv.Atoi(value)
+	if err != nil || level < 1 || level > trainer.MaxLevel {
+		return 0, fmt.Errorf("level must be a number from 1 to %d", trainer.MaxLevel)
+	}
+	return level, nil
+}
+
+func lookupNature(cfg *Config, name string) (trainer.Nature, error) {
+	nature, err := cfg.PokeapiClient.GetNature(strings.ToLower(name))
+	if err != nil {
+		return trainer.Nature{}, err
+	}
+
+	res := trainer.Nature{Name: nature.Name}
+	if nature.IncreasedStat != nil {
+		res.Increased = nature.IncreasedStat.Name
+	}
+	if nature.DecreasedStat != nil {
+		res.Decreased = nature.DecreasedStat.Name
+	}
+	return res, nil
+}
+
+// randomNature picks one of the natures PokeAPI lists with the config's
+// random source.
+func randomNature(cfg *Config) (trainer.Nature, error) {
+	natures, err := cfg.PokeapiClient.ListNatures()
+	if err != nil {
+		return trainer.Nature{}, err
+	}
+	if len(natures) == 0 {
+		return trainer.Nature{}, nil
+	}
+	return lookupNature(cfg, natures[cfg.Rand.Intn(len(natures))].Name)
+}
+
+// randomAbility picks one of the species' regular abilities. Hidden
+// abilities are never found in the wild.
+func randomAbility(cfg *Config, pokemon pokeapi.Pokemon) string {
+	var abilities []string
+	for _, abilityEntry := range pokemon.Abilities {
+		if !abilityEntry.IsHidden {
+			abilities = append(abilities, abilityEntry.Ability.Name)
+		}
+	}
+	if len(abilities) == 0 {
+		return ""
+	}
+	return abilities[cfg.Rand.Intn(len(abilities))]
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/GrahamZiervogel/pokedex/internal/mirror"
+)
+
+// runSync downloads PokeAPI resources into the data directory, which the
+// REPL then reads from instead of the network. Interrupting it with
+// Ctrl-C keeps everything downloaded so far for the next run.
+func runSync(args []string) error {
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: pokedex sync [flags] [resource...]\n\nresources default to: %s\n\n", strings.Join(mirror.DefaultResources, ", "))
+		fs.PrintDefaults()
+	}
+	configPath := fs.String("config", "", "read settings from `file`")
+	dir := fs.String("dir", "", "data `directory` (default the data_dir setting)")
+	baseURL := fs.String("base-url", "", "PokeAPI base `URL` to download from (default the base_url setting)")
+	workers := fs.Int("workers", 4, "number of concurrent downloads")
+	rate := fs.Float64("rate", 10, "maximum requests per second, or 0 for no limit")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *workers < 1 {
+		return fmt.Errorf("sync: --workers must be at least 1")
+	}
+	if *rate < 0 {
+		return fmt.Errorf("sync: --rate cannot be negative")
+	}
+
+	settings, err := loadSettings(*configPath, false)
+	if err != nil {
+		return err
+	}
+	if *dir != "" {
+		settings.DataDir = *dir
+	}
+	if *baseURL != "" {
+		settings.BaseURL = strings.TrimSuffix(*baseURL, "/")
+	}
+	if settings.DataDir == "" {
+		return fmt.Errorf("sync: no data directory, pass --dir")
+	}
+
+	resources := mirror.DefaultResources
+	if fs.NArg() > 0 {
+		resources = fs.Args()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Syncing %s into %s\n", settings.BaseURL, settings.DataDir)
+	syncer := &mirror.Syncer{
+		BaseURL:   settings.BaseURL,
+		Dir:       settings.DataDir,
+		Resources: resources,
+		Workers:   *workers,
+		Rate:      *rate,
+		Client:    &http.Client{Timeout: settings.HTTPTimeout},
+		Log:       os.Stdout,
+	}
+	if err := syncer.Sync(ctx); err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+
+	fmt.Println("Sync complete.")
+	return nil
+}